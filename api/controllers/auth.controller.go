@@ -5,6 +5,7 @@ import (
 	"SalaryAdvance/internal/services"
 	"SalaryAdvance/pkg/config"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +32,7 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
 		return
 	}
-	access, refresh, err := ctrl.authUseCase.Login(c.Request.Context(), req.Email, req.Password)
+	access, refresh, err := ctrl.authUseCase.Login(c.Request.Context(), req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
 		return
@@ -60,10 +61,95 @@ func (ctrl *AuthController) Refresh(c *gin.Context) {
 		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
 		return
 	}
-	access, err := ctrl.authUseCase.RefreshToken(c.Request.Context(), req.RefreshToken)
+	access, refresh, err := ctrl.authUseCase.RefreshToken(c.Request.Context(), req.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"access_token": access})
+	c.JSON(http.StatusOK, gin.H{"access_token": access, "refresh_token": refresh})
+}
+
+func (ctrl *AuthController) Logout(c *gin.Context) {
+	var req domain.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.authUseCase.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// ForgotPassword answers the same whether or not the email is registered.
+// Every request for a registered email stores a reset link and sends an
+// email, so requests are limited per email and per client IP, registered or
+// not, under keys kept apart from the login attempts.
+func (ctrl *AuthController) ForgotPassword(c *gin.Context) {
+	var req domain.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	for _, key := range []string{"forgot:email:" + strings.ToLower(strings.TrimSpace(req.Email)), "forgot:ip:" + c.ClientIP()} {
+		if allowed, _ := ctrl.rateLimiter.CheckAndIncrement(key); !allowed {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many password reset requests, try again later"})
+			return
+		}
+	}
+	if err := ctrl.authUseCase.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "if the email is registered, a password reset link has been sent"})
+}
+
+func (ctrl *AuthController) ResetPassword(c *gin.Context) {
+	var req domain.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.authUseCase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully"})
+}
+
+// ChangePassword keeps the session of the request and logs out the others.
+func (ctrl *AuthController) ChangePassword(c *gin.Context) {
+	var req domain.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	err := ctrl.authUseCase.ChangePassword(c.Request.Context(), c.GetUint("user_id"), c.GetString("session_id"), req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "password changed successfully"})
+}
+
+func (ctrl *AuthController) ListSessions(c *gin.Context) {
+	sessions, err := ctrl.authUseCase.ListSessions(c.Request.Context(), c.GetUint("user_id"), c.GetString("session_id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (ctrl *AuthController) RevokeSession(c *gin.Context) {
+	if err := ctrl.authUseCase.RevokeSession(c.Request.Context(), c.GetUint("user_id"), c.Param("id")); err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked"})
+}
+
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
 }
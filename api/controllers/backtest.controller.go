@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BacktestController struct {
+	backtestUseCase domain.BacktestUseCase
+}
+
+func NewBacktestController(uc domain.BacktestUseCase) *BacktestController {
+	return &BacktestController{backtestUseCase: uc}
+}
+
+func (ctrl *BacktestController) LoadOutcomes(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to get file from form"})
+		return
+	}
+	defer file.Close()
+
+	stored, err := ctrl.backtestUseCase.LoadOutcomes(c.Request.Context(), file)
+	if err != nil {
+		c.JSON(backtestStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"stored": stored})
+}
+
+// Run backtests the scorecard versions in the comma-separated versions query
+// parameter. An outcome file may be uploaded for a one-off run; otherwise the
+// stored outcomes are used.
+func (ctrl *BacktestController) Run(c *gin.Context) {
+	var versions []string
+	for _, version := range strings.Split(c.Query("versions"), ",") {
+		if version = strings.TrimSpace(version); version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	var outcomes io.Reader
+	if file, _, err := c.Request.FormFile("file"); err == nil {
+		defer file.Close()
+		outcomes = file
+	}
+
+	comparison, err := ctrl.backtestUseCase.Run(c.Request.Context(), c.Query("productName"), versions, outcomes)
+	if err != nil {
+		c.JSON(backtestStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
+
+// backtestStatusCode maps outcome file errors, which carry the offending
+// record, to 400.
+func backtestStatusCode(err error) int {
+	if errors.Is(err, config.ErrInvalidOutcome) {
+		return http.StatusBadRequest
+	}
+	return config.GetStatusCode(err)
+}
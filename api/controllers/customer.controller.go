@@ -1,18 +1,24 @@
 package controllers
 
 import (
+	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/internal/usecases"
+	"SalaryAdvance/pkg/config"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type CustomerController struct {
-	uc *usecases.CustomerUseCase
+	uc            *usecases.CustomerUseCase
+	ratingWorkers int
 }
 
-func NewCustomerController(uc *usecases.CustomerUseCase) *CustomerController {
-	return &CustomerController{uc: uc}
+func NewCustomerController(uc *usecases.CustomerUseCase, ratingWorkers int) *CustomerController {
+	return &CustomerController{uc: uc, ratingWorkers: ratingWorkers}
 }
 
 func (ctrl *CustomerController) ImportCustomers(c *gin.Context) {
@@ -23,13 +29,25 @@ func (ctrl *CustomerController) ImportCustomers(c *gin.Context) {
 	}
 	defer file.Close()
 
+	dryRun := c.Query("dryRun") == "true"
+
 	ctx := c.Request.Context()
-	customers, logs, err := ctrl.uc.ImportCustomers(ctx, file)
+	customers, logs, err := ctrl.uc.ImportCustomers(ctx, file, dryRun)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if dryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Dry run completed; no customers were imported",
+			"dry_run": true,
+			"data":    customers,
+			"logs":    logs,
+		})
+		return
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Customers imported",
 		"data":    customers,
@@ -67,14 +85,25 @@ func (ctrl *CustomerController) ImportTransactions(c *gin.Context) {
 	defer file.Close()
 
 	allowOverdraft := c.Query("allowOverdraft") == "true"
+	dryRun := c.Query("dryRun") == "true"
 
 	ctx := c.Request.Context()
-	transactions, logs, err := ctrl.uc.ImportTransactions(ctx, file, allowOverdraft)
+	transactions, logs, err := ctrl.uc.ImportTransactions(ctx, file, allowOverdraft, dryRun)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if dryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Dry run completed; no transactions were imported",
+			"dry_run": true,
+			"data":    transactions,
+			"logs":    logs,
+		})
+		return
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Transactions imported",
 		"data":    transactions,
@@ -85,13 +114,127 @@ func (ctrl *CustomerController) ImportTransactions(c *gin.Context) {
 func (ctrl *CustomerController) CalculateCustomerRating(c *gin.Context) {
 	id := c.Param("id")
 	ctx := c.Request.Context()
-	rating, err := ctrl.uc.CalculateCustomerRating(ctx, id)
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+
+	rating, err := ctrl.uc.CalculateCustomerRating(ctx, id, asOf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rating)
+}
+
+func (ctrl *CustomerController) RateAllCustomers(c *gin.Context) {
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+	summary, err := ctrl.uc.RateAllCustomers(c.Request.Context(), asOf, ctrl.ratingWorkers)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, summary)
+}
+
+func (ctrl *CustomerController) SimulateCustomerRating(c *gin.Context) {
+	var req domain.RatingSimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	rating, err := ctrl.uc.SimulateCustomerRating(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rating)
+}
+
+func (ctrl *CustomerController) RatingHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	snapshots, err := ctrl.uc.RatingHistory(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
+
+func (ctrl *CustomerController) ListReviews(c *gin.Context) {
+	ctx := c.Request.Context()
+	reviews, err := ctrl.uc.ListReviews(ctx, c.Query("status"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reviews)
+}
+
+func (ctrl *CustomerController) ApproveReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid review id"})
+		return
+	}
+	var req domain.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	customer, err := ctrl.uc.ApproveReview(ctx, uint(id), c.GetUint("user_id"), req.Note)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Review approved",
+		"data":    customer,
+	})
+}
+
+func (ctrl *CustomerController) RejectReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid review id"})
+		return
+	}
+	var req domain.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	review, err := ctrl.uc.RejectReview(ctx, uint(id), c.GetUint("user_id"), req.Note)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"customer_id": id,
-		"rating":      rating,
+		"message": "Review rejected",
+		"data":    review,
 	})
 }
+
+// parseAsOf reads the optional asOf query parameter, a YYYY-MM-DD date whose
+// transactions are all included, and writes a 400 response when it is invalid.
+func parseAsOf(c *gin.Context) (time.Time, bool) {
+	value := c.Query("asOf")
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid asOf date, expected YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	if date.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "asOf date cannot be in the future"})
+		return time.Time{}, false
+	}
+	return date.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+}
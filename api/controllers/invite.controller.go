@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/pkg/config"
 
@@ -26,10 +27,60 @@ func (ctrl *InviteController) SendInvite(c *gin.Context) {
 		return
 	}
 	adminID := c.GetUint("user_id")
-	link, err := ctrl.inviteUseCase.SendInvite(c.Request.Context(), adminID, req.Email)
+	link, err := ctrl.inviteUseCase.SendInvite(c.Request.Context(), adminID, req.Email, req.Role)
 	if err != nil {
 		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"invite_link": link})
-}
\ No newline at end of file
+}
+
+// ValidateInvite tells the registration page whether the token in the query
+// belongs to a pending invite and which email it was sent to.
+func (ctrl *InviteController) ValidateInvite(c *gin.Context) {
+	email, err := ctrl.inviteUseCase.ValidateInvite(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"email": email})
+}
+
+// ListInvites returns the invites, optionally filtered by the status query
+// parameter (pending, expired, used or revoked).
+func (ctrl *InviteController) ListInvites(c *gin.Context) {
+	invites, err := ctrl.inviteUseCase.ListInvites(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invites)
+}
+
+func (ctrl *InviteController) ResendInvite(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid invite id"})
+		return
+	}
+	link, err := ctrl.inviteUseCase.ResendInvite(c.Request.Context(), c.GetUint("user_id"), uint(id))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"invite_link": link})
+}
+
+func (ctrl *InviteController) RevokeInvite(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid invite id"})
+		return
+	}
+	invite, err := ctrl.inviteUseCase.RevokeInvite(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invite)
+}
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSController struct {
+	jwtService domain.JWTService
+}
+
+func NewJWKSController(jwtService domain.JWTService) *JWKSController {
+	return &JWKSController{jwtService: jwtService}
+}
+
+// JWKS publishes the token verification keys. Verifiers may cache them
+// briefly; a token with an unknown kid means the keys should be fetched again.
+func (ctrl *JWKSController) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, ctrl.jwtService.JWKS())
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MasterCustomerController struct {
+	masterUseCase domain.MasterCustomerUseCase
+}
+
+func NewMasterCustomerController(uc domain.MasterCustomerUseCase) *MasterCustomerController {
+	return &MasterCustomerController{masterUseCase: uc}
+}
+
+func (ctrl *MasterCustomerController) List(c *gin.Context) {
+	includeInactive := c.Query("includeInactive") == "true"
+	customers, err := ctrl.masterUseCase.List(c.Request.Context(), includeInactive)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, customers)
+}
+
+func (ctrl *MasterCustomerController) Create(c *gin.Context) {
+	var req domain.MasterCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	customer, err := ctrl.masterUseCase.Create(c.Request.Context(), &req, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, customer)
+}
+
+func (ctrl *MasterCustomerController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid customer id"})
+		return
+	}
+	var req domain.MasterCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	customer, err := ctrl.masterUseCase.Update(c.Request.Context(), id, &req, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
+
+func (ctrl *MasterCustomerController) Deactivate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid customer id"})
+		return
+	}
+	customer, err := ctrl.masterUseCase.Deactivate(c.Request.Context(), id, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
+
+func (ctrl *MasterCustomerController) History(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid customer id"})
+		return
+	}
+	changes, err := ctrl.masterUseCase.History(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+func (ctrl *MasterCustomerController) BulkUpsert(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to get file from form"})
+		return
+	}
+	defer file.Close()
+
+	summary, logs, err := ctrl.masterUseCase.BulkUpsert(c.Request.Context(), file, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Master customers loaded",
+		"summary": summary,
+		"logs":    logs,
+	})
+}
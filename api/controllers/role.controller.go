@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	permissionUseCase domain.PermissionUseCase
+}
+
+func NewRoleController(uc domain.PermissionUseCase) *RoleController {
+	return &RoleController{permissionUseCase: uc}
+}
+
+func (ctrl *RoleController) List(c *gin.Context) {
+	roles, err := ctrl.permissionUseCase.ListRoles(c.Request.Context())
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// SetPermissions replaces the permissions granted to the :role path
+// parameter.
+func (ctrl *RoleController) SetPermissions(c *gin.Context) {
+	var req domain.RolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	role, err := ctrl.permissionUseCase.SetRolePermissions(c.Request.Context(), c.Param("role"), req.Permissions)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScorecardController struct {
+	scorecardUseCase domain.ScorecardUseCase
+}
+
+func NewScorecardController(uc domain.ScorecardUseCase) *ScorecardController {
+	return &ScorecardController{scorecardUseCase: uc}
+}
+
+func (ctrl *ScorecardController) List(c *gin.Context) {
+	scorecards, err := ctrl.scorecardUseCase.List(c.Request.Context(), c.Query("productName"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scorecards)
+}
+
+func (ctrl *ScorecardController) Create(c *gin.Context) {
+	var req domain.ScorecardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	scorecard, err := ctrl.scorecardUseCase.Create(c.Request.Context(), &req, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, scorecard)
+}
+
+func (ctrl *ScorecardController) Activate(c *gin.Context) {
+	scorecard, err := ctrl.scorecardUseCase.Activate(c.Request.Context(), c.Query("productName"), c.Param("version"))
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scorecard)
+}
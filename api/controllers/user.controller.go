@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserController struct {
+	userUseCase domain.UserUseCase
+}
+
+func NewUserController(uc domain.UserUseCase) *UserController {
+	return &UserController{userUseCase: uc}
+}
+
+// List returns the page given by the page and pageSize query parameters.
+func (ctrl *UserController) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+	users, err := ctrl.userUseCase.ListUsers(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (ctrl *UserController) Get(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := ctrl.userUseCase.GetUser(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (ctrl *UserController) UpdateRole(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	var req domain.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	user, err := ctrl.userUseCase.UpdateRole(c.Request.Context(), c.GetUint("user_id"), id, req.Role)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (ctrl *UserController) Deactivate(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := ctrl.userUseCase.Deactivate(c.Request.Context(), c.GetUint("user_id"), id)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (ctrl *UserController) Reactivate(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := ctrl.userUseCase.Reactivate(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (ctrl *UserController) Delete(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.userUseCase.DeleteUser(c.Request.Context(), c.GetUint("user_id"), id); err != nil {
+		c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user deleted"})
+}
+
+// userIDParam parses the :id path parameter, answering 400 when it is not a
+// user ID.
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(config.GetStatusCode(config.ErrBadRequest), gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type AuthMiddleware struct {
-	jwtService domain.JWTService
+	jwtService  domain.JWTService
+	permissions domain.PermissionUseCase
 }
 
-func NewAuthMiddleware(jwtService domain.JWTService) *AuthMiddleware {
-	return &AuthMiddleware{jwtService: jwtService}
+func NewAuthMiddleware(jwtService domain.JWTService, permissions domain.PermissionUseCase) *AuthMiddleware {
+	return &AuthMiddleware{jwtService: jwtService, permissions: permissions}
 }
 
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
@@ -32,40 +33,49 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		claims, err := m.jwtService.ValidateToken(tokenString)
+		claims, err := m.jwtService.ValidateAccessToken(tokenString)
 		if err != nil {
 			fmt.Printf("Token validation failed: %v\n", err)
 			c.JSON(config.GetStatusCode(config.ErrUnauthorized), gin.H{"error": fmt.Sprintf("invalid token: %v", err)})
 			c.Abort()
 			return
 		}
-		userID, ok := claims["id"].(float64)
-		if !ok {
-			fmt.Printf("Invalid user ID claim: %v\n", claims["id"])
+		if claims.UserID == 0 {
+			fmt.Printf("Invalid user ID claim: %v\n", claims.UserID)
 			c.JSON(config.GetStatusCode(config.ErrUnauthorized), gin.H{"error": "invalid token claims: user ID missing or invalid"})
 			c.Abort()
 			return
 		}
-		role, ok := claims["role"].(string)
-		if !ok {
-			fmt.Printf("Invalid role claim: %v\n", claims["role"])
+		if claims.Role == "" {
+			fmt.Printf("Invalid role claim: %v\n", claims.Role)
 			c.JSON(config.GetStatusCode(config.ErrUnauthorized), gin.H{"error": "invalid token claims: role missing or invalid"})
 			c.Abort()
 			return
 		}
-		c.Set("user_id", uint(userID))
-		c.Set("role", role)
+		c.Set("user_id", claims.UserID)
+		c.Set("role", claims.Role)
+		c.Set("session_id", claims.SessionID)
 		c.Next()
 	}
 }
 
-func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
+// RequirePermission must run after RequireAuth. It lets the request through
+// only when the caller's role holds every one of permissions.
+func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != "admin" {
-			c.JSON(config.GetStatusCode(config.ErrForbidden), gin.H{"error": "admin access required"})
-			c.Abort()
-			return
+		role := c.GetString("role")
+		for _, permission := range permissions {
+			allowed, err := m.permissions.HasPermission(c.Request.Context(), role, permission)
+			if err != nil {
+				c.JSON(config.GetStatusCode(err), gin.H{"error": err.Error()})
+				c.Abort()
+				return
+			}
+			if !allowed {
+				c.JSON(config.GetStatusCode(config.ErrForbidden), gin.H{"error": fmt.Sprintf("permission %s required", permission)})
+				c.Abort()
+				return
+			}
 		}
 		c.Next()
 	}
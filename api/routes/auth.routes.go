@@ -12,26 +12,58 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupAuthRoutes(userRoute *gin.RouterGroup, db *gorm.DB, jwtService domain.JWTService) {
+func SetupAuthRoutes(userRoute *gin.RouterGroup, db *gorm.DB, jwtService domain.JWTService, authMiddleware *middleware.AuthMiddleware, permissionUsecase domain.PermissionUseCase) {
 	userRepo := repositories.NewUserRepository(db)
 	inviteRepo := repositories.NewInviteRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
 	emailService := services.NewEmailService()
-	authUsecase := usecases.NewAuthUseCase(userRepo, jwtService)
+	authUsecase := usecases.NewAuthUseCase(userRepo, inviteRepo, refreshTokenRepo, repositories.NewPasswordResetRepository(db), jwtService, emailService)
 	inviteUsecase := usecases.NewInviteUseCase(inviteRepo, userRepo, emailService, jwtService)
 	rateLimiter := services.NewLoginRateLimiter()
 	authCtrl := controllers.NewAuthController(authUsecase, rateLimiter)
 	inviteCtrl := controllers.NewInviteController(inviteUsecase)
+	userCtrl := controllers.NewUserController(usecases.NewUserUseCase(userRepo, refreshTokenRepo))
+	roleCtrl := controllers.NewRoleController(permissionUsecase)
 
 	auth := userRoute.Group("/auth")
 	{
 		auth.POST("/login", authCtrl.Login)
 		auth.POST("/register", authCtrl.Register)
 		auth.POST("/refresh", authCtrl.Refresh)
+		auth.POST("/logout", authCtrl.Logout)
+		auth.POST("/password/forgot", authCtrl.ForgotPassword)
+		auth.POST("/password/reset", authCtrl.ResetPassword)
+		auth.POST("/password/change", authMiddleware.RequireAuth(), authCtrl.ChangePassword)
+		auth.GET("/sessions", authMiddleware.RequireAuth(), authCtrl.ListSessions)
+		auth.DELETE("/sessions/:id", authMiddleware.RequireAuth(), authCtrl.RevokeSession)
 	}
 
+	userRoute.GET("/invite/validate", inviteCtrl.ValidateInvite)
+
 	invite := userRoute.Group("/invite")
-	invite.Use(middleware.NewAuthMiddleware(jwtService).RequireAuth(), middleware.NewAuthMiddleware(jwtService).RequireAdmin())
+	invite.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionInvitesManage))
 	{
 		invite.POST("/send", inviteCtrl.SendInvite)
+		invite.GET("", inviteCtrl.ListInvites)
+		invite.POST("/:id/resend", inviteCtrl.ResendInvite)
+		invite.POST("/:id/revoke", inviteCtrl.RevokeInvite)
+	}
+
+	users := userRoute.Group("/users")
+	users.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionUsersManage))
+	{
+		users.GET("", userCtrl.List)
+		users.GET("/:id", userCtrl.Get)
+		users.PATCH("/:id/role", userCtrl.UpdateRole)
+		users.POST("/:id/deactivate", userCtrl.Deactivate)
+		users.POST("/:id/reactivate", userCtrl.Reactivate)
+		users.DELETE("/:id", userCtrl.Delete)
+	}
+
+	roles := userRoute.Group("/roles")
+	roles.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionRolesManage))
+	{
+		roles.GET("", roleCtrl.List)
+		roles.PUT("/:role/permissions", roleCtrl.SetPermissions)
 	}
 }
\ No newline at end of file
@@ -5,6 +5,9 @@ import (
 	"SalaryAdvance/api/middleware"
 	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/internal/repositories"
+	"SalaryAdvance/internal/services"
+	"SalaryAdvance/pkg/config"
+	"log"
 
 	"SalaryAdvance/internal/usecases"
 
@@ -12,18 +15,74 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupCustomerRoutes(customerRoute *gin.RouterGroup, db *gorm.DB, jwtService domain.JWTService) {
+func SetupCustomerRoutes(customerRoute *gin.RouterGroup, cfg *config.Config, db *gorm.DB, authMiddleware *middleware.AuthMiddleware) {
 	repo := repositories.NewCustomerRepository(db)
-	uc := usecases.NewCustomerUseCase(repo)
-	ctrl := controllers.NewCustomerController(uc)
+	scorecardRepo := repositories.NewScorecardRepository(db)
+	nameMatcher := services.NewNameMatcher(cfg.NameMatchAutoThreshold, cfg.NameMatchReviewThreshold)
+	enricher := services.NewCustomerEnricher(cfg.FieldPrecedence)
+	strategy, err := usecases.NewRatingStrategy(cfg.RatingStrategy)
+	if err != nil {
+		log.Fatalf("Invalid RATING_STRATEGY: %v", err)
+	}
+	shadowStrategy, err := usecases.NewRatingStrategy(cfg.ShadowRatingStrategy)
+	if err != nil {
+		log.Fatalf("Invalid RATING_SHADOW_STRATEGY: %v", err)
+	}
+	uc := usecases.NewCustomerUseCase(repo, scorecardRepo, repositories.NewRatingRepository(db), nameMatcher, enricher, strategy, shadowStrategy)
+	ctrl := controllers.NewCustomerController(uc, cfg.RatingBatchWorkers)
+	masterCtrl := controllers.NewMasterCustomerController(usecases.NewMasterCustomerUseCase(repositories.NewMasterCustomerRepository(db)))
+	scorecardCtrl := controllers.NewScorecardController(usecases.NewScorecardUseCase(scorecardRepo))
+	backtestCtrl := controllers.NewBacktestController(usecases.NewBacktestUseCase(repo, scorecardRepo, repositories.NewRepaymentOutcomeRepository(db)))
+
+	scorecardRoute := customerRoute.Group("/scorecards")
+	scorecardRoute.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionScorecardsManage))
+	{
+		scorecardRoute.GET("", scorecardCtrl.List)
+		scorecardRoute.POST("", scorecardCtrl.Create)
+		scorecardRoute.POST("/:version/activate", scorecardCtrl.Activate)
+	}
+
+	backtestRoute := customerRoute.Group("/backtests")
+	backtestRoute.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionScorecardsManage))
+	{
+		backtestRoute.POST("", backtestCtrl.Run)
+		backtestRoute.POST("/outcomes", backtestCtrl.LoadOutcomes)
+	}
+
+	masterRoute := customerRoute.Group("/master")
+	masterRoute.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionCustomersManage))
+	{
+		masterRoute.GET("", masterCtrl.List)
+		masterRoute.POST("", masterCtrl.Create)
+		masterRoute.POST("/bulk", masterCtrl.BulkUpsert)
+		masterRoute.PUT("/:id", masterCtrl.Update)
+		masterRoute.POST("/:id/deactivate", masterCtrl.Deactivate)
+		masterRoute.GET("/:id/history", masterCtrl.History)
+	}
+
+	ratingRoute := customerRoute.Group("/ratings")
+	ratingRoute.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionRatingsBatch))
+	{
+		ratingRoute.POST("/batch", ctrl.RateAllCustomers)
+	}
+
+	reviewRoute := customerRoute.Group("/reviews")
+	reviewRoute.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission(domain.PermissionCustomersManage))
+	{
+		reviewRoute.GET("", ctrl.ListReviews)
+		reviewRoute.POST("/:id/approve", ctrl.ApproveReview)
+		reviewRoute.POST("/:id/reject", ctrl.RejectReview)
+	}
 
 	authCustomerRoute := customerRoute.Group("/")
-	authCustomerRoute.Use(middleware.NewAuthMiddleware(jwtService).RequireAuth())
+	authCustomerRoute.Use(authMiddleware.RequireAuth())
 	{
-		authCustomerRoute.POST("/import", ctrl.ImportCustomers)
-		authCustomerRoute.GET("/:id", ctrl.GetCustomer)
-		authCustomerRoute.GET("/", ctrl.GetAllCustomers)
-		authCustomerRoute.POST("/transactions/import", ctrl.ImportTransactions)
-		authCustomerRoute.GET("/:id/rating", ctrl.CalculateCustomerRating)
+		authCustomerRoute.POST("/import", authMiddleware.RequirePermission(domain.PermissionCustomersImport), ctrl.ImportCustomers)
+		authCustomerRoute.GET("/:id", authMiddleware.RequirePermission(domain.PermissionCustomersRead), ctrl.GetCustomer)
+		authCustomerRoute.GET("/", authMiddleware.RequirePermission(domain.PermissionCustomersRead), ctrl.GetAllCustomers)
+		authCustomerRoute.POST("/transactions/import", authMiddleware.RequirePermission(domain.PermissionTransactionsImport), ctrl.ImportTransactions)
+		authCustomerRoute.GET("/:id/rating", authMiddleware.RequirePermission(domain.PermissionRatingsRead), ctrl.CalculateCustomerRating)
+		authCustomerRoute.GET("/:id/ratings", authMiddleware.RequirePermission(domain.PermissionRatingsRead), ctrl.RatingHistory)
+		authCustomerRoute.POST("/:id/rating/simulate", authMiddleware.RequirePermission(domain.PermissionRatingsRead), ctrl.SimulateCustomerRating)
 	}
 }
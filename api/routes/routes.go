@@ -1,17 +1,33 @@
 package routes
 
 import (
+	"SalaryAdvance/api/controllers"
+	"SalaryAdvance/api/middleware"
+	"SalaryAdvance/api/validators"
+	"SalaryAdvance/internal/repositories"
 	"SalaryAdvance/internal/services"
+	"SalaryAdvance/internal/usecases"
 	"SalaryAdvance/pkg/config"
+	"log"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func SetupRoutes(r *gin.RouterGroup, cfg *config.Config, db *gorm.DB) {
+func SetupRoutes(router *gin.Engine, cfg *config.Config, db *gorm.DB) {
+
+	if err := validators.RegisterPasswordRules(); err != nil {
+		log.Fatalf("Failed to register password rules: %v", err)
+	}
 
 	jwtService := services.NewJWTService(cfg)
 
-	SetupCustomerRoutes(r.Group("/customers"), db, jwtService)
-	SetupAuthRoutes(r.Group("/user"), db, jwtService)
+	router.GET("/.well-known/jwks.json", controllers.NewJWKSController(jwtService).JWKS)
+
+	permissionUsecase := usecases.NewPermissionUseCase(repositories.NewRolePermissionRepository(db))
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, permissionUsecase)
+
+	r := router.Group("/api/v0")
+	SetupCustomerRoutes(r.Group("/customers"), cfg, db, authMiddleware)
+	SetupAuthRoutes(r.Group("/user"), db, jwtService, authMiddleware, permissionUsecase)
 }
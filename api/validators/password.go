@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterPasswordRules adds the containsuppercase, containslowercase,
+// containsnumber and containsspecial tags used by the password fields of the
+// request structs to gin's validator.
+func RegisterPasswordRules() error {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+	rules := map[string]func(rune) bool{
+		"containsuppercase": unicode.IsUpper,
+		"containslowercase": unicode.IsLower,
+		"containsnumber":    unicode.IsDigit,
+		"containsspecial":   isSpecial,
+	}
+	for tag, class := range rules {
+		if err := engine.RegisterValidation(tag, containsRune(class)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsRune(class func(rune) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), class) >= 0
+	}
+}
+
+func isSpecial(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
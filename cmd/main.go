@@ -3,11 +3,15 @@ package main
 import (
 	"SalaryAdvance/api/routes"
 	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/repositories"
 	"SalaryAdvance/internal/services"
+	"SalaryAdvance/internal/usecases"
 	"SalaryAdvance/migration"
 	"SalaryAdvance/pkg/config"
 	"SalaryAdvance/pkg/database"
+	"context"
 	"log"
+	"os"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -41,6 +45,19 @@ func main() {
 
 	db.AutoMigrate(&domain.User{}, &domain.Invite{}, &domain.Customer{})
 
+	if cfg.ScorecardFile != "" {
+		file, err := os.Open(cfg.ScorecardFile)
+		if err != nil {
+			log.Fatalf("Failed to open scorecard file: %v", err)
+		}
+		created, err := usecases.NewScorecardUseCase(repositories.NewScorecardRepository(db)).LoadDefinitions(context.Background(), file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("Failed to load scorecards: %v", err)
+		}
+		log.Printf("Loaded %d scorecard(s) from %s", created, cfg.ScorecardFile)
+	}
+
 	var admin domain.User
 	if err := db.Where("email = ?", cfg.AdminEmail).First(&admin).Error; err != nil {
 		hashedPassword, _ := services.HashPassword(cfg.AdminPassword)
@@ -53,11 +70,15 @@ func main() {
 		log.Println("Admin user seeded successfully")
 	}
 
+	if err := usecases.NewPermissionUseCase(repositories.NewRolePermissionRepository(db)).SeedDefaults(context.Background()); err != nil {
+		log.Fatalf("Failed to seed role permissions: %v", err)
+	}
+
 	router := gin.Default()
 
 	router.Use(cors.Default())
 
-	routes.SetupRoutes(router.Group("/api/v0"), &cfg, db)
+	routes.SetupRoutes(router, &cfg, db)
 
 	log.Printf("Server is running on port %s\n", cfg.AppPort)
 	if err := router.Run(cfg.AppPort); err != nil {
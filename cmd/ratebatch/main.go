@@ -0,0 +1,68 @@
+// Command ratebatch rates every customer in valid_customers, stores the
+// results as rating snapshots and prints the distribution summary as JSON.
+//
+//	go run ./cmd/ratebatch -asOf 2025-01-31 -workers 8
+package main
+
+import (
+	"SalaryAdvance/internal/repositories"
+	"SalaryAdvance/internal/services"
+	"SalaryAdvance/internal/usecases"
+	"SalaryAdvance/pkg/config"
+	"SalaryAdvance/pkg/database"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	cfg := config.LoadConfig()
+
+	asOfFlag := flag.String("asOf", "", "rate as of this date (YYYY-MM-DD); defaults to now")
+	workers := flag.Int("workers", cfg.RatingBatchWorkers, "number of customers rated concurrently")
+	flag.Parse()
+
+	var asOf time.Time
+	if *asOfFlag != "" {
+		date, err := time.Parse("2006-01-02", *asOfFlag)
+		if err != nil {
+			log.Fatalf("Invalid -asOf date %q, expected YYYY-MM-DD", *asOfFlag)
+		}
+		asOf = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	strategy, err := usecases.NewRatingStrategy(cfg.RatingStrategy)
+	if err != nil {
+		log.Fatalf("Invalid RATING_STRATEGY: %v", err)
+	}
+	shadowStrategy, err := usecases.NewRatingStrategy(cfg.ShadowRatingStrategy)
+	if err != nil {
+		log.Fatalf("Invalid RATING_SHADOW_STRATEGY: %v", err)
+	}
+
+	db := database.ConnectDB()
+	uc := usecases.NewCustomerUseCase(
+		repositories.NewCustomerRepository(db),
+		repositories.NewScorecardRepository(db),
+		repositories.NewRatingRepository(db),
+		services.NewNameMatcher(cfg.NameMatchAutoThreshold, cfg.NameMatchReviewThreshold),
+		services.NewCustomerEnricher(cfg.FieldPrecedence),
+		strategy,
+		shadowStrategy,
+	)
+
+	summary, err := uc.RateAllCustomers(context.Background(), asOf, *workers)
+	if err != nil {
+		log.Fatalf("Batch rating failed: %v", err)
+	}
+	log.Printf("Rated %d of %d customers in %s", summary.Rated, summary.Total, summary.Duration)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(summary); err != nil {
+		log.Fatalf("Failed to write summary: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Repayment outcomes of a past salary advance.
+const (
+	OutcomeRepaid    = "repaid"
+	OutcomeLate      = "late"
+	OutcomeDefaulted = "defaulted"
+)
+
+// RepaymentOutcome is the observed result of an advance. The customer is
+// rated as of DecisionDate, the day the advance was granted.
+type RepaymentOutcome struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	LoanID       string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"loanId"`
+	CustomerID   string    `gorm:"type:varchar(50);not null;index" json:"customerId"`
+	DecisionDate time.Time `gorm:"not null" json:"decisionDate"`
+	Outcome      string    `gorm:"type:varchar(20);not null" json:"outcome"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// RepaymentOutcomeInput is one record of an uploaded outcome file.
+type RepaymentOutcomeInput struct {
+	LoanID       string `json:"loanId"`
+	CustomerID   string `json:"customerId"`
+	DecisionDate string `json:"decisionDate"`
+	Outcome      string `json:"outcome"`
+}
+
+// BacktestBucket holds the outcomes of the advances whose rating fell in Band.
+type BacktestBucket struct {
+	Band        string  `json:"band"`
+	Count       int     `json:"count"`
+	Late        int     `json:"late"`
+	Defaulted   int     `json:"defaulted"`
+	LateRate    float64 `json:"late_rate"`
+	DefaultRate float64 `json:"default_rate"`
+}
+
+// BacktestReport measures how well one scorecard version separates defaulted
+// advances from the rest. Gini and KS are 0 when either group is empty.
+type BacktestReport struct {
+	ScorecardVersion string           `json:"scorecard_version"`
+	Population       int              `json:"population"`
+	Late             int              `json:"late"`
+	Defaulted        int              `json:"defaulted"`
+	DefaultRate      float64          `json:"default_rate"`
+	Gini             float64          `json:"gini"`
+	KS               float64          `json:"ks"`
+	Buckets          []BacktestBucket `json:"buckets"`
+}
+
+// BacktestComparison reports one or two scorecard versions on the same
+// population. Outcomes whose customer could not be rated are listed in Skipped
+// and left out of every report.
+type BacktestComparison struct {
+	ProductName string           `json:"product_name,omitempty"`
+	Population  int              `json:"population"`
+	Skipped     []RatingFailure  `json:"skipped"`
+	Reports     []BacktestReport `json:"reports"`
+}
+
+type RepaymentOutcomeRepository interface {
+	SaveOutcomes(ctx context.Context, outcomes []*RepaymentOutcome) error
+	ListOutcomes(ctx context.Context) ([]*RepaymentOutcome, error)
+}
+
+type BacktestUseCase interface {
+	LoadOutcomes(ctx context.Context, file io.Reader) (int, error)
+	Run(ctx context.Context, productName string, versions []string, outcomes io.Reader) (*BacktestComparison, error)
+}
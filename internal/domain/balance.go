@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyntheticAccountPrefix marks the counterparty of the placeholder debits
+// generated for customers without any imported transactions.
+const SyntheticAccountPrefix = "SYNTHETIC-"
+
+func (t *Transaction) IsSynthetic() bool {
+	return strings.HasPrefix(string(t.ToAccount), SyntheticAccountPrefix)
+}
+
+// BalanceEntry is one transaction applied to an account. Amount is signed
+// (debits are negative) and Balance is the balance right after it.
+type BalanceEntry struct {
+	TransactionID string    `json:"transaction_id"`
+	Date          time.Time `json:"date"`
+	Amount        float64   `json:"amount"`
+	Balance       float64   `json:"balance"`
+	Synthetic     bool      `json:"synthetic,omitempty"`
+}
+
+// DailyBalance is an account's end-of-day balance.
+type DailyBalance struct {
+	Date    time.Time `json:"date"`
+	Balance float64   `json:"balance"`
+}
+
+// BalanceHistory is an account's balance rebuilt from its transactions in
+// chronological order, for rating, statements and reporting alike.
+type BalanceHistory struct {
+	AccountNo      AccountNo      `json:"account_no"`
+	OpeningBalance float64        `json:"opening_balance"`
+	ClosingBalance float64        `json:"closing_balance"`
+	Entries        []BalanceEntry `json:"entries"`
+}
+
+// NewBalanceHistory rebuilds the history of accountNo ending at
+// closingBalance, the account's current balance. Transactions are ordered by
+// date, then creation time, then ID. Self-transfers leave the balance
+// unchanged and are skipped; transactions of other accounts are ignored.
+// Synthetic debits moved the stored balance, so they are kept and flagged.
+func NewBalanceHistory(accountNo AccountNo, closingBalance float64, transactions []*Transaction) *BalanceHistory {
+	ordered := make([]*Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if (tx.FromAccount == accountNo) != (tx.ToAccount == accountNo) {
+			ordered = append(ordered, tx)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.TransactionID < b.TransactionID
+	})
+
+	history := &BalanceHistory{
+		AccountNo:      accountNo,
+		ClosingBalance: closingBalance,
+		Entries:        make([]BalanceEntry, 0, len(ordered)),
+	}
+	var net float64
+	for _, tx := range ordered {
+		amount := tx.Amount
+		if tx.FromAccount == accountNo {
+			amount = -amount
+		}
+		net += amount
+		history.Entries = append(history.Entries, BalanceEntry{
+			TransactionID: tx.TransactionID,
+			Date:          tx.Date,
+			Amount:        amount,
+			Synthetic:     tx.IsSynthetic(),
+		})
+	}
+
+	history.OpeningBalance = closingBalance - net
+	balance := history.OpeningBalance
+	for i := range history.Entries {
+		balance += history.Entries[i].Amount
+		history.Entries[i].Balance = balance
+	}
+	return history
+}
+
+// BalanceAt returns the balance after every transaction dated at or before t.
+func (h *BalanceHistory) BalanceAt(t time.Time) float64 {
+	balance := h.OpeningBalance
+	for _, entry := range h.Entries {
+		if entry.Date.After(t) {
+			break
+		}
+		balance = entry.Balance
+	}
+	return balance
+}
+
+// Daily returns the end-of-day balance of every calendar day from the first
+// to the last transaction, carrying the balance over days without activity.
+func (h *BalanceHistory) Daily() []DailyBalance {
+	if len(h.Entries) == 0 {
+		return []DailyBalance{}
+	}
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	var daily []DailyBalance
+	current := day(h.Entries[0].Date)
+	last := day(h.Entries[len(h.Entries)-1].Date)
+	next := 0
+	balance := h.OpeningBalance
+	for !current.After(last) {
+		for next < len(h.Entries) && !day(h.Entries[next].Date).After(current) {
+			balance = h.Entries[next].Balance
+			next++
+		}
+		daily = append(daily, DailyBalance{Date: current, Balance: balance})
+		current = current.AddDate(0, 0, 1)
+	}
+	return daily
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -11,17 +12,19 @@ type AccountNo string
 
 
 type Customer struct {
-	ID              int       `gorm:"primaryKey;autoIncrement" json:"id"`
-	CustomerId      string    `gorm:"type:varchar(255);unique;not null" validate:"required" json:"customerId"`
-	CustomerName    string    `gorm:"type:varchar(255);not null" validate:"required,min=3,max=255" json:"customerName"`
-	Mobile          string    `gorm:"type:varchar(255)" validate:"omitempty,min=10,max=15" json:"mobile"`
-	AccountNo       AccountNo `gorm:"type:varchar(255);not null" validate:"required" json:"accountNo"`
-	BranchName      string    `gorm:"type:varchar(255)" json:"branchName"`
-	BranchCode      string    `gorm:"type:varchar(255)" json:"branchCode"`
-	ProductName     string    `gorm:"type:varchar(255)" json:"productName"`
-	CustomerBalance float64   `gorm:"type:decimal(15,2);default:0" json:"customerBalance"`
-	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              int          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerId      string       `gorm:"type:varchar(255);unique;not null" validate:"required" json:"customerId"`
+	CustomerName    string       `gorm:"type:varchar(255);not null" validate:"required,min=3,max=255" json:"customerName"`
+	Mobile          string       `gorm:"type:varchar(255)" validate:"omitempty,min=10,max=15" json:"mobile"`
+	AccountNo       AccountNo    `gorm:"type:varchar(255);not null" validate:"required" json:"accountNo"`
+	BranchName      string       `gorm:"type:varchar(255)" json:"branchName"`
+	BranchCode      string       `gorm:"type:varchar(255)" json:"branchCode"`
+	ProductName     string       `gorm:"type:varchar(255)" json:"productName"`
+	CustomerBalance float64      `gorm:"type:decimal(15,2);default:0" json:"customerBalance"`
+	FieldSources    FieldSources `gorm:"type:text" json:"fieldSources,omitempty"`
+	DeactivatedAt   *time.Time   `json:"deactivatedAt,omitempty"`
+	CreatedAt       time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
 }
 func (a *AccountNo) UnmarshalJSON(data []byte) error {
 	var num float64
@@ -40,4 +43,54 @@ func (a *AccountNo) UnmarshalJSON(data []byte) error {
 
 	*a = AccountNo(str)
 	return nil
-}
\ No newline at end of file
+}
+
+const (
+	FieldSourceImport = "import"
+	FieldSourceMaster = "master"
+)
+
+// FieldSources records, per enriched field, whether its value came from the
+// uploaded file or from the customers master table.
+type FieldSources map[string]string
+
+func (f FieldSources) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return jsonValue(f)
+}
+
+func (f *FieldSources) Scan(value interface{}) error {
+	return jsonScan(value, f)
+}
+
+// jsonValue and jsonScan store map-like fields as JSON in text columns.
+func jsonValue(v interface{}) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func jsonScan(value interface{}, dst interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for JSON column: %T", value)
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// CustomerEnricher fills the optional fields of a customer being promoted into
+// valid_customers from the uploaded record and the matched master record.
+type CustomerEnricher interface {
+	Enrich(target *Customer, submitted *Customer, master *Customer)
+}
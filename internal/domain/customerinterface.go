@@ -3,11 +3,13 @@ package domain
 import (
 	"context"
 	"io"
+	"time"
 )
 
 type CustomerRepository interface {
 	Create(ctx context.Context, customer *Customer) (*Customer, error)
 	FindByNameAndAccountNo(ctx context.Context, name string, accountNo string) (*Customer, error)
+	FindByAccountNo(ctx context.Context, accountNo string) (*Customer, error)
 	FindByID(ctx context.Context, id string) (*Customer, error)
 	FindAll(ctx context.Context) ([]*Customer, error)
 	CheckDuplicateInValidCustomers(ctx context.Context, name string, accountNo string) (*Customer, error)
@@ -18,12 +20,24 @@ type CustomerRepository interface {
 	HasTransactions(ctx context.Context, accountNo string) (bool, error)
 	GetTransactionsByAccount(ctx context.Context, accountNo string) ([]*Transaction, error)
 	GetTransactionsByCustomerId(ctx context.Context, customerId string) ([]*Transaction, error)
+
+	CreateReview(ctx context.Context, review *CustomerReview) (*CustomerReview, error)
+	FindReviewByID(ctx context.Context, id uint) (*CustomerReview, error)
+	ListReviews(ctx context.Context, status string) ([]*CustomerReview, error)
+	UpdateReview(ctx context.Context, review *CustomerReview) (*CustomerReview, error)
+	FindPendingReviews(ctx context.Context, matchedCustomerID int) ([]*CustomerReview, error)
 }
 
 type CustomerUseCase interface {
-	ImportCustomers(ctx context.Context, customers []*Customer) ([]*Customer, []map[string]interface{}, error)
+	ImportCustomers(ctx context.Context, file io.Reader, dryRun bool) ([]*Customer, []*ValidationResult, error)
 	GetCustomer(ctx context.Context, id string) (*Customer, error)
 	GetAllCustomers(ctx context.Context) ([]*Customer, error)
-	ImportTransactions(ctx context.Context, file io.Reader, allowOverdraft bool) ([]*Transaction, []map[string]interface{}, error)
-	CalculateCustomerRating(ctx context.Context, id string) (float64, error)
+	ImportTransactions(ctx context.Context, file io.Reader, allowOverdraft bool, dryRun bool) ([]*Transaction, []*ValidationResult, error)
+	CalculateCustomerRating(ctx context.Context, id string, asOf time.Time) (*RatingResult, error)
+	RatingHistory(ctx context.Context, id string) ([]*RatingSnapshot, error)
+	SimulateCustomerRating(ctx context.Context, id string, req *RatingSimulationRequest) (*RatingResult, error)
+	RateAllCustomers(ctx context.Context, asOf time.Time, workers int) (*RatingBatchSummary, error)
+	ListReviews(ctx context.Context, status string) ([]*CustomerReview, error)
+	ApproveReview(ctx context.Context, id uint, reviewerID uint, note string) (*Customer, error)
+	RejectReview(ctx context.Context, id uint, reviewerID uint, note string) (*CustomerReview, error)
 }
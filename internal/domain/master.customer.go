@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"time"
+)
+
+const (
+	ChangeActionCreate     = "create"
+	ChangeActionUpdate     = "update"
+	ChangeActionDeactivate = "deactivate"
+	ChangeActionReactivate = "reactivate"
+)
+
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+type FieldChanges map[string]FieldChange
+
+func (f FieldChanges) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return jsonValue(f)
+}
+
+func (f *FieldChanges) Scan(value interface{}) error {
+	return jsonScan(value, f)
+}
+
+// CustomerChange is one entry in the change history of a record in the
+// customers master table.
+type CustomerChange struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	CustomerID int          `gorm:"not null;index" json:"customerId"`
+	AccountNo  AccountNo    `gorm:"type:varchar(255);not null" json:"accountNo"`
+	Action     string       `gorm:"type:varchar(20);not null" json:"action"`
+	Changes    FieldChanges `gorm:"type:text" json:"changes"`
+	ChangedBy  uint         `json:"changedBy"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type MasterCustomerRequest struct {
+	CustomerId      string    `json:"customerId"`
+	CustomerName    string    `json:"customerName" binding:"required,min=3,max=255"`
+	AccountNo       AccountNo `json:"accountNo" binding:"required"`
+	Mobile          string    `json:"mobile" binding:"omitempty,min=10,max=15"`
+	BranchName      string    `json:"branchName"`
+	BranchCode      string    `json:"branchCode"`
+	ProductName     string    `json:"productName"`
+	CustomerBalance float64   `json:"customerBalance"`
+}
+
+type BulkUpsertSummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+type MasterCustomerRepository interface {
+	Create(ctx context.Context, customer *Customer, change *CustomerChange) (*Customer, error)
+	Update(ctx context.Context, customer *Customer, change *CustomerChange) (*Customer, error)
+	FindByID(ctx context.Context, id int) (*Customer, error)
+	FindByAccountNo(ctx context.Context, accountNo string) (*Customer, error)
+	List(ctx context.Context, includeInactive bool) ([]*Customer, error)
+	History(ctx context.Context, customerID int) ([]*CustomerChange, error)
+}
+
+type MasterCustomerUseCase interface {
+	Create(ctx context.Context, req *MasterCustomerRequest, adminID uint) (*Customer, error)
+	Update(ctx context.Context, id int, req *MasterCustomerRequest, adminID uint) (*Customer, error)
+	Deactivate(ctx context.Context, id int, adminID uint) (*Customer, error)
+	BulkUpsert(ctx context.Context, file io.Reader, adminID uint) (*BulkUpsertSummary, []*ValidationResult, error)
+	List(ctx context.Context, includeInactive bool) ([]*Customer, error)
+	History(ctx context.Context, id int) ([]*CustomerChange, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Permissions guard individual routes; roles are granted permissions through
+// the role_permissions table rather than being checked by name.
+const (
+	PermissionCustomersRead      = "customers:read"
+	PermissionCustomersImport    = "customers:import"
+	PermissionCustomersManage    = "customers:manage"
+	PermissionTransactionsImport = "transactions:import"
+	PermissionRatingsRead        = "ratings:read"
+	PermissionRatingsBatch       = "ratings:batch"
+	PermissionScorecardsManage   = "scorecards:manage"
+	PermissionLoansApprove       = "loans:approve"
+	PermissionUsersManage        = "users:manage"
+	PermissionInvitesManage      = "invites:manage"
+	PermissionRolesManage        = "roles:manage"
+)
+
+func AllPermissions() []string {
+	return []string{
+		PermissionCustomersRead,
+		PermissionCustomersImport,
+		PermissionCustomersManage,
+		PermissionTransactionsImport,
+		PermissionRatingsRead,
+		PermissionRatingsBatch,
+		PermissionScorecardsManage,
+		PermissionLoansApprove,
+		PermissionUsersManage,
+		PermissionInvitesManage,
+		PermissionRolesManage,
+	}
+}
+
+func IsValidPermission(permission string) bool {
+	for _, known := range AllPermissions() {
+		if permission == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions are the grants seeded into an empty role_permissions
+// table.
+func DefaultRolePermissions() map[string][]string {
+	return map[string][]string{
+		RoleAdmin:       AllPermissions(),
+		RoleUploader:    {PermissionCustomersRead, PermissionCustomersImport, PermissionTransactionsImport},
+		RoleAnalyst:     {PermissionCustomersRead, PermissionRatingsRead},
+		RoleLoanOfficer: {PermissionCustomersRead, PermissionRatingsRead, PermissionLoansApprove},
+	}
+}
+
+type RolePermission struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Role       string    `gorm:"not null;uniqueIndex:idx_role_permission" json:"role"`
+	Permission string    `gorm:"not null;uniqueIndex:idx_role_permission" json:"permission"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// RolePermissions is a role with every permission granted to it.
+type RolePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+type RolePermissionRepository interface {
+	List(ctx context.Context) ([]*RolePermission, error)
+	ReplaceForRole(ctx context.Context, role string, permissions []string) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// PermissionUseCase answers permission checks from a cache of the stored
+// grants and lets admins change the grants of every role but admin, which
+// always holds every permission so it cannot be locked out.
+type PermissionUseCase interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+	ListRoles(ctx context.Context) ([]*RolePermissions, error)
+	SetRolePermissions(ctx context.Context, role string, permissions []string) (*RolePermissions, error)
+	SeedDefaults(ctx context.Context) error
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// Rating strategy names, as used by RATING_STRATEGY and RATING_SHADOW_STRATEGY.
+const (
+	StrategyScorecard = "scorecard"
+	StrategyRules     = "rules"
+)
+
+// RatingInput is what a RatingStrategy rates: the customer with the balance
+// they had at AsOf, their transactions up to AsOf and the scorecard of their
+// product.
+type RatingInput struct {
+	CustomerID   string
+	Customer     *Customer
+	Transactions []*Transaction
+	Scorecard    *Scorecard
+	AsOf         time.Time
+}
+
+type RatingStrategy interface {
+	Name() string
+	Rate(input *RatingInput) *RatingResult
+}
+
+// RatingRule awards Points when the raw value of Factor is at least Min and,
+// when Max is set, at most Max.
+type RatingRule struct {
+	Factor string   `json:"factor"`
+	Min    float64  `json:"min"`
+	Max    *float64 `json:"max,omitempty"`
+	Points float64  `json:"points"`
+}
+
+// DefaultRatingRules are the rules of the rules-based strategy: on top of the
+// minimum rating, points for a regular salary, activity, history, volume and
+// a steady balance, for a maximum of 10.
+func DefaultRatingRules() []RatingRule {
+	maxStdDev := 5000.0
+	return []RatingRule{
+		{Factor: FactorSalary, Min: 3, Points: 3},
+		{Factor: FactorCount, Min: 10, Points: 2},
+		{Factor: FactorDuration, Min: 180, Points: 2},
+		{Factor: FactorVolume, Min: 10000, Points: 1},
+		{Factor: FactorStability, Min: 0, Max: &maxStdDev, Points: 1},
+	}
+}
+
+type FactorBreakdowns []FactorBreakdown
+
+func (f FactorBreakdowns) Value() (driver.Value, error) {
+	return jsonValue(f)
+}
+
+func (f *FactorBreakdowns) Scan(value interface{}) error {
+	return jsonScan(value, f)
+}
+
+type RatingReasons []RatingReason
+
+func (r RatingReasons) Value() (driver.Value, error) {
+	return jsonValue(r)
+}
+
+func (r *RatingReasons) Scan(value interface{}) error {
+	return jsonScan(value, r)
+}
+
+// RatingSnapshot is a stored rating, kept so that the score behind a past
+// decision, and how a customer's score moved over time, can be shown later.
+type RatingSnapshot struct {
+	ID                     uint             `gorm:"primaryKey" json:"id"`
+	CustomerID             string           `gorm:"type:varchar(50);not null;index" json:"customer_id"`
+	Rating                 float64          `gorm:"not null" json:"rating"`
+	ScorecardVersion       string           `gorm:"type:varchar(50);not null" json:"scorecard_version"`
+	Strategy               string           `gorm:"type:varchar(50);not null;default:'scorecard'" json:"strategy"`
+	EstimatedMonthlySalary float64          `gorm:"type:decimal(15,2);not null;default:0" json:"estimated_monthly_salary"`
+	EligibleLimit          float64          `gorm:"type:decimal(15,2);not null;default:0" json:"eligible_limit"`
+	Factors                FactorBreakdowns `gorm:"type:text" json:"factors"`
+	Reasons                RatingReasons    `gorm:"type:text" json:"reasons"`
+	AsOf                   time.Time        `gorm:"not null;index" json:"as_of"`
+	CreatedAt              time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func NewRatingSnapshot(result *RatingResult) *RatingSnapshot {
+	return &RatingSnapshot{
+		CustomerID:             result.CustomerID,
+		Rating:                 result.Rating,
+		ScorecardVersion:       result.ScorecardVersion,
+		Strategy:               result.Strategy,
+		EstimatedMonthlySalary: result.EstimatedMonthlySalary,
+		EligibleLimit:          result.EligibleLimit,
+		Factors:                FactorBreakdowns(result.Factors),
+		Reasons:                RatingReasons(result.Reasons),
+		AsOf:                   result.AsOf,
+	}
+}
+
+// SimulatedTransaction is a hypothetical credit to or debit from the
+// customer's account. Date is YYYY-MM-DD; Counterparty is optional.
+type SimulatedTransaction struct {
+	Direction    string  `json:"direction" binding:"required,oneof=credit debit"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	Date         string  `json:"date" binding:"required"`
+	Counterparty string  `json:"counterparty"`
+}
+
+// RatingSimulationRequest asks for a rating as if the hypothetical
+// transactions had happened. AsOf defaults to the later of today and the last
+// hypothetical transaction.
+type RatingSimulationRequest struct {
+	Transactions []SimulatedTransaction `json:"transactions" binding:"required,min=1,dive"`
+	AsOf         string                 `json:"asOf"`
+}
+
+// RatingFailure records a customer the batch run could not rate.
+type RatingFailure struct {
+	CustomerID string `json:"customer_id"`
+	Error      string `json:"error"`
+}
+
+// RatingBatchSummary describes a portfolio rating run. Distribution counts
+// ratings per one-point band ("1-2" ... "9-10"; a 10 falls in "9-10").
+type RatingBatchSummary struct {
+	AsOf         time.Time       `json:"as_of"`
+	Total        int             `json:"total"`
+	Rated        int             `json:"rated"`
+	Failed       int             `json:"failed"`
+	Mean         float64         `json:"mean"`
+	Median       float64         `json:"median"`
+	Min          float64         `json:"min"`
+	Max          float64         `json:"max"`
+	Distribution map[string]int  `json:"distribution"`
+	ByScorecard  map[string]int  `json:"by_scorecard"`
+	Failures     []RatingFailure `json:"failures"`
+	Duration     string          `json:"duration"`
+}
+
+type RatingRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot *RatingSnapshot) (*RatingSnapshot, error)
+	ListSnapshots(ctx context.Context, customerID string) ([]*RatingSnapshot, error)
+}
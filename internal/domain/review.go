@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// MatchDecision is the outcome of comparing a submitted customer name with the
+// name held in the customers table for the same account.
+type MatchDecision string
+
+const (
+	MatchAccepted MatchDecision = "accepted"
+	MatchReview   MatchDecision = "review"
+	MatchRejected MatchDecision = "rejected"
+)
+
+// CustomerReview is a mid-confidence name match waiting for an admin to
+// approve or reject it before the record is promoted into valid_customers.
+type CustomerReview struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	SubmittedName     string     `gorm:"type:varchar(255);not null" json:"submittedName"`
+	AccountNo         AccountNo  `gorm:"type:varchar(255);not null;index" json:"accountNo"`
+	MatchedCustomerID int        `gorm:"not null" json:"matchedCustomerId"`
+	MatchedName       string     `gorm:"type:varchar(255);not null" json:"matchedName"`
+	Confidence        float64    `gorm:"type:decimal(5,4);not null" json:"confidence"`
+	Status            string     `gorm:"type:varchar(20);not null;default:pending;index" json:"status"`
+	ReviewedBy        uint       `json:"reviewedBy,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewedAt,omitempty"`
+	Note              string     `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type NameMatcher interface {
+	Match(submitted, reference string) (float64, MatchDecision)
+}
+
+type ReviewDecisionRequest struct {
+	Note string `json:"note"`
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"time"
+)
+
+// Rating factors understood by the scorecard engine.
+const (
+	FactorCount     = "count"
+	FactorVolume    = "volume"
+	FactorDuration  = "duration"
+	FactorStability = "stability"
+	FactorSalary    = "salary"
+)
+
+// ScorecardFactor weights one rating factor. Cap is the raw value at which the
+// factor saturates: count, volume, duration and salary (the number of regular
+// monthly salary credits) score value/Cap up to 1, while stability scores
+// 1 - stdDev/Cap down to 0.
+type ScorecardFactor struct {
+	Name   string  `json:"name" binding:"required"`
+	Weight float64 `json:"weight" binding:"gte=0"`
+	Cap    float64 `json:"cap" binding:"gt=0"`
+}
+
+type ScorecardFactors []ScorecardFactor
+
+func (f ScorecardFactors) Value() (driver.Value, error) {
+	return jsonValue(f)
+}
+
+func (f *ScorecardFactors) Scan(value interface{}) error {
+	return jsonScan(value, f)
+}
+
+// Scorecard is a versioned rating definition. An empty ProductName is the
+// default scorecard used for products without one of their own; at most one
+// scorecard per product is active at a time. LimitRatio is the share of the
+// estimated monthly salary a top-rated customer may draw as an advance.
+type Scorecard struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	Version     string           `gorm:"type:varchar(50);not null;uniqueIndex:idx_scorecard_product_version" json:"version"`
+	ProductName string           `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_scorecard_product_version" json:"productName"`
+	Factors     ScorecardFactors `gorm:"type:text;not null" json:"factors"`
+	Scale       float64          `gorm:"not null;default:10" json:"scale"`
+	MinRating   float64          `gorm:"not null;default:1" json:"minRating"`
+	MaxRating   float64          `gorm:"not null;default:10" json:"maxRating"`
+	LimitRatio  float64          `gorm:"not null;default:0.5" json:"limitRatio"`
+	Active      bool             `gorm:"not null;default:false" json:"active"`
+	CreatedBy   uint             `json:"createdBy,omitempty"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BuiltinScorecards are the rating definitions shipped with the service,
+// oldest first. They are never stored, but can be backtested by version.
+// default-v1 reproduces the original hard-coded rating formula; default-v2
+// adds the salary factor and is the one in use.
+func BuiltinScorecards() []*Scorecard {
+	return []*Scorecard{
+		{
+			Version: "default-v1",
+			Factors: ScorecardFactors{
+				{Name: FactorCount, Weight: 0.3, Cap: 10},
+				{Name: FactorVolume, Weight: 0.3, Cap: 10000},
+				{Name: FactorDuration, Weight: 0.2, Cap: 365},
+				{Name: FactorStability, Weight: 0.2, Cap: 10000},
+			},
+			Scale:      10,
+			MinRating:  1,
+			MaxRating:  10,
+			LimitRatio: 0.5,
+		},
+		{
+			Version: "default-v2",
+			Factors: ScorecardFactors{
+				{Name: FactorCount, Weight: 0.25, Cap: 10},
+				{Name: FactorVolume, Weight: 0.25, Cap: 10000},
+				{Name: FactorDuration, Weight: 0.15, Cap: 365},
+				{Name: FactorStability, Weight: 0.15, Cap: 10000},
+				{Name: FactorSalary, Weight: 0.2, Cap: 6},
+			},
+			Scale:      10,
+			MinRating:  1,
+			MaxRating:  10,
+			LimitRatio: 0.5,
+			Active:     true,
+		},
+	}
+}
+
+// BuiltinScorecard returns the built-in scorecard with the given version, or
+// nil when there is none.
+func BuiltinScorecard(version string) *Scorecard {
+	for _, scorecard := range BuiltinScorecards() {
+		if scorecard.Version == version {
+			return scorecard
+		}
+	}
+	return nil
+}
+
+// DefaultScorecard is the newest built-in scorecard and is used when no
+// scorecard has been stored.
+func DefaultScorecard() *Scorecard {
+	builtin := BuiltinScorecards()
+	return builtin[len(builtin)-1]
+}
+
+// Reason codes returned with a rating to explain its weakest factors.
+const (
+	ReasonNoTransactions  = "NO_TRANSACTIONS"
+	ReasonLowCount        = "LOW_TRANSACTION_COUNT"
+	ReasonLowVolume       = "LOW_TRANSACTION_VOLUME"
+	ReasonShortHistory    = "SHORT_TRANSACTION_HISTORY"
+	ReasonUnstableBalance = "UNSTABLE_BALANCE"
+	ReasonIrregularSalary = "IRREGULAR_SALARY"
+)
+
+// FactorBreakdown shows how one scorecard factor contributed to a rating.
+// Contribution is in rating points, i.e. Weight * NormalizedScore * Scale.
+type FactorBreakdown struct {
+	Name            string  `json:"name"`
+	RawValue        float64 `json:"raw_value"`
+	NormalizedScore float64 `json:"normalized_score"`
+	Weight          float64 `json:"weight"`
+	Contribution    float64 `json:"contribution"`
+}
+
+type RatingReason struct {
+	Code    string `json:"code"`
+	Factor  string `json:"factor,omitempty"`
+	Message string `json:"message"`
+}
+
+// RatingResult is a computed customer rating together with the scorecard
+// version that produced it and the per-factor breakdown behind it.
+// EligibleLimit is the advance the customer may draw: the estimated monthly
+// salary times the scorecard's LimitRatio, scaled by Rating/MaxRating.
+type RatingResult struct {
+	CustomerID             string            `json:"customer_id"`
+	Rating                 float64           `json:"rating"`
+	ScorecardVersion       string            `json:"scorecard_version"`
+	Strategy               string            `json:"strategy"`
+	AsOf                   time.Time         `json:"as_of"`
+	EstimatedMonthlySalary float64           `json:"estimated_monthly_salary"`
+	EligibleLimit          float64           `json:"eligible_limit"`
+	Simulated              bool              `json:"simulated,omitempty"`
+	Factors                []FactorBreakdown `json:"factors"`
+	Reasons                []RatingReason    `json:"reasons"`
+}
+
+type ScorecardRequest struct {
+	Version     string            `json:"version" binding:"required,max=50"`
+	ProductName string            `json:"productName"`
+	Factors     []ScorecardFactor `json:"factors" binding:"required,min=1,dive"`
+	Scale       float64           `json:"scale"`
+	MinRating   float64           `json:"minRating"`
+	MaxRating   float64           `json:"maxRating"`
+	LimitRatio  float64           `json:"limitRatio"`
+	Active      *bool             `json:"active"`
+}
+
+type ScorecardRepository interface {
+	Create(ctx context.Context, scorecard *Scorecard) (*Scorecard, error)
+	FindActive(ctx context.Context, productName string) (*Scorecard, error)
+	FindByVersion(ctx context.Context, productName string, version string) (*Scorecard, error)
+	List(ctx context.Context, productName string) ([]*Scorecard, error)
+	Activate(ctx context.Context, scorecard *Scorecard) (*Scorecard, error)
+}
+
+type ScorecardUseCase interface {
+	Create(ctx context.Context, req *ScorecardRequest, adminID uint) (*Scorecard, error)
+	List(ctx context.Context, productName string) ([]*Scorecard, error)
+	Activate(ctx context.Context, productName string, version string) (*Scorecard, error)
+	LoadDefinitions(ctx context.Context, file io.Reader) (int, error)
+}
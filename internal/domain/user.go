@@ -5,28 +5,185 @@ import (
 )
 
 type User struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Email     string    `gorm:"unique;not null" json:"email" validate:"required,email"`
-	Password  string    `gorm:"not null" json:"password" validate:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
-	Role      string    `gorm:"not null" json:"role"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Email    string `gorm:"unique;not null" json:"email" validate:"required,email"`
+	Password string `gorm:"not null" json:"-" validate:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
+	Role     string `gorm:"not null" json:"role"`
+	// DeactivatedAt is set while the account is deactivated; such users can
+	// neither log in nor refresh their tokens.
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+const (
+	RoleAdmin       = "admin"
+	RoleUploader    = "uploader"
+	RoleAnalyst     = "analyst"
+	RoleLoanOfficer = "loan_officer"
+)
+
+// IsValidRole reports whether role can be assigned to a user.
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleUploader, RoleAnalyst, RoleLoanOfficer:
+		return true
+	}
+	return false
+}
+
+// UserPage is one page of the user list, ordered by ID.
+type UserPage struct {
+	Users    []*User `json:"users"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+	Total    int64   `json:"total"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// Token types carried in the typ claim, so a token issued for one purpose is
+// rejected where another is expected.
+const (
+	TokenTypeAccess        = "access"
+	TokenTypeRefresh       = "refresh"
+	TokenTypeInvite        = "invite"
+	TokenTypePasswordReset = "password_reset"
+)
+
+// TokenClaims are the claims of a validated token. Which fields are set
+// depends on Type: access tokens carry the user and the SessionID of the login
+// they belong to, refresh tokens the user ID and JTI, invite tokens the invited
+// Email, Role and InvitedBy.
+type TokenClaims struct {
+	UserID    uint
+	Email     string
+	Role      string
+	InvitedBy uint
+	Type      string
+	JTI       string
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// JSONWebKey is the public half of a token signing key as published in the
+// JWKS, so other services can verify tokens by their kid header.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []JSONWebKey `json:"keys"`
 }
 
 type Invite struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Token     string    `gorm:"unique;not null" json:"token"`
-	Email     string    `gorm:"not null" json:"email" validate:"required,email"`
-	Expiry    time.Time `gorm:"not null" json:"expiry"`
-	Used      bool      `gorm:"default:false" json:"used"`
-	InvitedBy uint      `json:"invited_by"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Token     string     `gorm:"unique;not null" json:"-"`
+	Email     string     `gorm:"not null" json:"email" validate:"required,email"`
+	Role      string     `gorm:"not null;default:uploader" json:"role"`
+	Expiry    time.Time  `gorm:"not null" json:"expiry"`
+	Used      bool       `gorm:"default:false" json:"used"`
+	InvitedBy uint       `json:"invited_by"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Status    string     `gorm:"-" json:"status"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+const (
+	InviteStatusPending = "pending"
+	InviteStatusExpired = "expired"
+	InviteStatusUsed    = "used"
+	InviteStatusRevoked = "revoked"
+)
+
+// InviteStatus reports where the invite stands at now. Only pending invites
+// can be used to register.
+func (i *Invite) InviteStatus(now time.Time) string {
+	switch {
+	case i.Used:
+		return InviteStatusUsed
+	case i.RevokedAt != nil:
+		return InviteStatusRevoked
+	case !i.Expiry.After(now):
+		return InviteStatusExpired
+	}
+	return InviteStatusPending
+}
+
+func IsValidInviteStatus(status string) bool {
+	switch status {
+	case InviteStatusPending, InviteStatusExpired, InviteStatusUsed, InviteStatusRevoked:
+		return true
+	}
+	return false
+}
+
+// RefreshToken is the server-side record of an issued refresh token, keyed by
+// its jti claim. Every rotation revokes the presented token and issues a new
+// one in the same family, so presenting a revoked token means it was stolen
+// or replayed and the whole family is revoked. A family is one login session;
+// UserAgent, IP and LastUsedAt record the login or refresh that issued the
+// token.
+type RefreshToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	JTI        string     `gorm:"uniqueIndex;not null" json:"jti"`
+	FamilyID   string     `gorm:"index;not null" json:"family_id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy string     `json:"replaced_by,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IP         string     `json:"ip,omitempty"`
+	LastUsedAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP" json:"last_used_at"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ClientInfo describes the client behind a login or refresh.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session is a login of the user as shown to them: the refresh token family
+// identified by ID, with the client and time of its last login or refresh.
+// Current marks the session of the access token making the request.
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// PasswordReset is the server-side record of a password reset link, keyed by
+// the jti claim of its token. UsedAt is set when the link is redeemed, so each
+// link resets the password at most once.
+type PasswordReset struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	JTI       string     `gorm:"uniqueIndex;not null" json:"jti"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
+	Password string `json:"password" binding:"required"`
 }
 
 type RegisterRequest struct {
@@ -34,10 +191,27 @@ type RegisterRequest struct {
 	Password string `json:"password" binding:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
 }
 
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecial"`
+}
+
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// SendInviteRequest invites Email to register with Role, which defaults to
+// uploader.
 type SendInviteRequest struct {
 	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"`
 }
@@ -2,44 +2,107 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByID(ctx context.Context, id uint) (*User, error)
+	List(ctx context.Context, offset, limit int) ([]*User, int64, error)
 	Update(ctx context.Context, user *User) (*User, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 
+// InviteRepository persists invites. Accept marks a still unused invite used
+// and creates its user in one transaction, and reports whether it did, so an
+// invite registers at most one user even under concurrent registrations.
 type InviteRepository interface {
 	Create(ctx context.Context, invite *Invite) (*Invite, error)
 	FindByToken(ctx context.Context, token string) (*Invite, error)
+	FindByID(ctx context.Context, id uint) (*Invite, error)
+	List(ctx context.Context, status string) ([]*Invite, error)
 	Update(ctx context.Context, invite *Invite) (*Invite, error)
+	Accept(ctx context.Context, inviteID uint, user *User) (bool, error)
+}
+
+
+// RefreshTokenRepository persists issued refresh tokens. Revoke only revokes a
+// token that is still active and reports whether it did, so two concurrent
+// refreshes with the same token cannot both succeed.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) (*RefreshToken, error)
+	FindByJTI(ctx context.Context, jti string) (*RefreshToken, error)
+	Revoke(ctx context.Context, jti, replacedBy string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+	RevokeOtherFamilies(ctx context.Context, userID uint, familyID string) error
+	ListActiveForUser(ctx context.Context, userID uint) ([]*RefreshToken, error)
+}
+
+
+// PasswordResetRepository persists issued password reset links. Redeem only
+// redeems a link that is still unused and reports whether it did, so a link
+// cannot be redeemed twice. InvalidateAllForUser uses up every unused link of
+// a user once their password changed.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *PasswordReset) (*PasswordReset, error)
+	FindByJTI(ctx context.Context, jti string) (*PasswordReset, error)
+	Redeem(ctx context.Context, jti string, userID uint, hashedPassword string) (bool, error)
+	InvalidateAllForUser(ctx context.Context, userID uint) error
 }
 
 
 type JWTService interface {
-	GenerateAccessToken(user *User) (string, error)
-	GenerateRefreshToken(user *User) (string, error)
-	ValidateToken(tokenString string) (map[string]interface{}, error)
-	GenerateInviteToken(email string, invitedBy uint) (string, error)
+	GenerateAccessToken(user *User, sessionID string) (string, error)
+	GenerateRefreshToken(user *User, jti string) (string, time.Time, error)
+	GenerateInviteToken(email, role string, invitedBy uint) (string, error)
+	ValidateAccessToken(tokenString string) (*TokenClaims, error)
+	ValidateRefreshToken(tokenString string) (*TokenClaims, error)
+	ValidateInviteToken(tokenString string) (*TokenClaims, error)
+	GeneratePasswordResetToken(user *User, jti string) (string, time.Time, error)
+	ValidatePasswordResetToken(tokenString string) (*TokenClaims, error)
+	JWKS() *JWKS
 }
 
 
 type EmailService interface {
 	SendInvite(email, link string) error
+	SendPasswordReset(email, link string) error
 }
 
 
 type AuthUseCase interface {
-	Login(ctx context.Context, email, password string) (string, string, error)
+	Login(ctx context.Context, email, password string, client ClientInfo) (string, string, error)
 	RegisterFromInvite(ctx context.Context, token, password string) error
-	RefreshToken(ctx context.Context, refreshToken string) (string, error)
+	RefreshToken(ctx context.Context, refreshToken string, client ClientInfo) (string, string, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) error
+	ListSessions(ctx context.Context, userID uint, sessionID string) ([]*Session, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
 }
 
 
 type InviteUseCase interface {
-	SendInvite(ctx context.Context, adminID uint, email string) (string, error)
+	SendInvite(ctx context.Context, adminID uint, email, role string) (string, error)
 	ValidateInvite(ctx context.Context, token string) (string, error)
-}
\ No newline at end of file
+	ListInvites(ctx context.Context, status string) ([]*Invite, error)
+	ResendInvite(ctx context.Context, adminID, id uint) (string, error)
+	RevokeInvite(ctx context.Context, id uint) (*Invite, error)
+}
+
+
+// UserUseCase lets admins manage accounts. An admin cannot change the role of,
+// deactivate or delete their own account, so there is always an admin left.
+type UserUseCase interface {
+	ListUsers(ctx context.Context, page, pageSize int) (*UserPage, error)
+	GetUser(ctx context.Context, id uint) (*User, error)
+	UpdateRole(ctx context.Context, adminID, id uint, role string) (*User, error)
+	Deactivate(ctx context.Context, adminID, id uint) (*User, error)
+	Reactivate(ctx context.Context, id uint) (*User, error)
+	DeleteUser(ctx context.Context, adminID, id uint) error
+}
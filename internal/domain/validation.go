@@ -0,0 +1,76 @@
+package domain
+
+// ValidationErrorCode is a stable, machine-readable identifier for an import
+// validation failure. Clients should switch on the code, not the message.
+type ValidationErrorCode string
+
+const (
+	CodeRequired            ValidationErrorCode = "REQUIRED"
+	CodeInvalidFormat       ValidationErrorCode = "INVALID_FORMAT"
+	CodeInvalidAmount       ValidationErrorCode = "INVALID_AMOUNT"
+	CodeInvalidDate         ValidationErrorCode = "INVALID_DATE"
+	CodeAccountNotFound     ValidationErrorCode = "ACCOUNT_NOT_FOUND"
+	CodeNameMismatch        ValidationErrorCode = "NAME_MISMATCH"
+	CodePendingReview       ValidationErrorCode = "PENDING_REVIEW"
+	CodeDuplicateRecord     ValidationErrorCode = "DUPLICATE_RECORD"
+	CodeInsufficientBalance ValidationErrorCode = "INSUFFICIENT_BALANCE"
+	CodeValidationFailed    ValidationErrorCode = "VALIDATION_FAILED"
+	CodeDatabaseError       ValidationErrorCode = "DATABASE_ERROR"
+	CodeSaveFailed          ValidationErrorCode = "SAVE_FAILED"
+)
+
+// Field names used in FieldError.Field, matching the JSON keys of the import files.
+const (
+	FieldCustomerName = "customerName"
+	FieldAccountNo    = "accountNo"
+	FieldFromAccount  = "fromAccount"
+	FieldToAccount    = "toAccount"
+	FieldAmount       = "amount"
+	FieldDate         = "date"
+)
+
+type FieldError struct {
+	Field   string              `json:"field,omitempty"`
+	Code    ValidationErrorCode `json:"code"`
+	Message string              `json:"message"`
+}
+
+// ValidationResult describes the outcome of importing a single record.
+// RecordIndex is 1-based; synthetic transactions use 0.
+type ValidationResult struct {
+	RecordIndex          int          `json:"record_index"`
+	Verified             bool         `json:"verified"`
+	Errors               []FieldError `json:"errors"`
+	AttemptedName        string       `json:"attempted_name,omitempty"`
+	AttemptedAccountNo   string       `json:"attempted_account_no,omitempty"`
+	AttemptedFromAccount string       `json:"attempted_from_account,omitempty"`
+	AttemptedToAccount   string       `json:"attempted_to_account,omitempty"`
+	AttemptedAmount      float64      `json:"attempted_amount,omitempty"`
+	NormalizedRecord     *Customer    `json:"normalized_record,omitempty"`
+	Transaction          *Transaction `json:"transaction,omitempty"`
+	Synthetic            bool         `json:"synthetic,omitempty"`
+	MatchConfidence      float64      `json:"match_confidence,omitempty"`
+	ReviewID             uint         `json:"review_id,omitempty"`
+}
+
+func NewValidationResult(recordIndex int) *ValidationResult {
+	return &ValidationResult{RecordIndex: recordIndex, Errors: []FieldError{}}
+}
+
+func (r *ValidationResult) AddError(field string, code ValidationErrorCode, message string) {
+	r.Errors = append(r.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (r *ValidationResult) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// HasCode reports whether any error on the record carries the given code.
+func (r *ValidationResult) HasCode(code ValidationErrorCode) bool {
+	for _, e := range r.Errors {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
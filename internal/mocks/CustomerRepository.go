@@ -74,6 +74,36 @@ func (_m *CustomerRepository) Create(ctx context.Context, customer *domain.Custo
 	return r0, r1
 }
 
+// CreateReview provides a mock function with given fields: ctx, review
+func (_m *CustomerRepository) CreateReview(ctx context.Context, review *domain.CustomerReview) (*domain.CustomerReview, error) {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReview")
+	}
+
+	var r0 *domain.CustomerReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CustomerReview) (*domain.CustomerReview, error)); ok {
+		return rf(ctx, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CustomerReview) *domain.CustomerReview); ok {
+		r0 = rf(ctx, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CustomerReview) error); ok {
+		r1 = rf(ctx, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTransaction provides a mock function with given fields: ctx, transaction
 func (_m *CustomerRepository) CreateTransaction(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
 	ret := _m.Called(ctx, transaction)
@@ -134,6 +164,36 @@ func (_m *CustomerRepository) FindAll(ctx context.Context) ([]*domain.Customer,
 	return r0, r1
 }
 
+// FindByAccountNo provides a mock function with given fields: ctx, accountNo
+func (_m *CustomerRepository) FindByAccountNo(ctx context.Context, accountNo string) (*domain.Customer, error) {
+	ret := _m.Called(ctx, accountNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccountNo")
+	}
+
+	var r0 *domain.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Customer, error)); ok {
+		return rf(ctx, accountNo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Customer); ok {
+		r0 = rf(ctx, accountNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Customer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *CustomerRepository) FindByID(ctx context.Context, id string) (*domain.Customer, error) {
 	ret := _m.Called(ctx, id)
@@ -194,6 +254,66 @@ func (_m *CustomerRepository) FindByNameAndAccountNo(ctx context.Context, name s
 	return r0, r1
 }
 
+// FindPendingReviews provides a mock function with given fields: ctx, matchedCustomerID
+func (_m *CustomerRepository) FindPendingReviews(ctx context.Context, matchedCustomerID int) ([]*domain.CustomerReview, error) {
+	ret := _m.Called(ctx, matchedCustomerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingReviews")
+	}
+
+	var r0 []*domain.CustomerReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.CustomerReview, error)); ok {
+		return rf(ctx, matchedCustomerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.CustomerReview); ok {
+		r0 = rf(ctx, matchedCustomerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CustomerReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, matchedCustomerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindReviewByID provides a mock function with given fields: ctx, id
+func (_m *CustomerRepository) FindReviewByID(ctx context.Context, id uint) (*domain.CustomerReview, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReviewByID")
+	}
+
+	var r0 *domain.CustomerReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*domain.CustomerReview, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *domain.CustomerReview); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx
 func (_m *CustomerRepository) GetAll(ctx context.Context) ([]*domain.Customer, error) {
 	ret := _m.Called(ctx)
@@ -312,6 +432,36 @@ func (_m *CustomerRepository) HasTransactions(ctx context.Context, accountNo str
 	return r0, r1
 }
 
+// ListReviews provides a mock function with given fields: ctx, status
+func (_m *CustomerRepository) ListReviews(ctx context.Context, status string) ([]*domain.CustomerReview, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviews")
+	}
+
+	var r0 []*domain.CustomerReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.CustomerReview, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.CustomerReview); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CustomerReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, customer
 func (_m *CustomerRepository) Update(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
 	ret := _m.Called(ctx, customer)
@@ -342,6 +492,36 @@ func (_m *CustomerRepository) Update(ctx context.Context, customer *domain.Custo
 	return r0, r1
 }
 
+// UpdateReview provides a mock function with given fields: ctx, review
+func (_m *CustomerRepository) UpdateReview(ctx context.Context, review *domain.CustomerReview) (*domain.CustomerReview, error) {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReview")
+	}
+
+	var r0 *domain.CustomerReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CustomerReview) (*domain.CustomerReview, error)); ok {
+		return rf(ctx, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CustomerReview) *domain.CustomerReview); ok {
+		r0 = rf(ctx, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CustomerReview) error); ok {
+		r1 = rf(ctx, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCustomerRepository creates a new instance of CustomerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCustomerRepository(t interface {
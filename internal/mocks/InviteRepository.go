@@ -14,6 +14,34 @@ type InviteRepository struct {
 	mock.Mock
 }
 
+// Accept provides a mock function with given fields: ctx, inviteID, user
+func (_m *InviteRepository) Accept(ctx context.Context, inviteID uint, user *domain.User) (bool, error) {
+	ret := _m.Called(ctx, inviteID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *domain.User) (bool, error)); ok {
+		return rf(ctx, inviteID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *domain.User) bool); ok {
+		r0 = rf(ctx, inviteID, user)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *domain.User) error); ok {
+		r1 = rf(ctx, inviteID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, invite
 func (_m *InviteRepository) Create(ctx context.Context, invite *domain.Invite) (*domain.Invite, error) {
 	ret := _m.Called(ctx, invite)
@@ -44,6 +72,36 @@ func (_m *InviteRepository) Create(ctx context.Context, invite *domain.Invite) (
 	return r0, r1
 }
 
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *InviteRepository) FindByID(ctx context.Context, id uint) (*domain.Invite, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Invite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*domain.Invite, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *domain.Invite); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByToken provides a mock function with given fields: ctx, token
 func (_m *InviteRepository) FindByToken(ctx context.Context, token string) (*domain.Invite, error) {
 	ret := _m.Called(ctx, token)
@@ -74,6 +132,36 @@ func (_m *InviteRepository) FindByToken(ctx context.Context, token string) (*dom
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, status
+func (_m *InviteRepository) List(ctx context.Context, status string) ([]*domain.Invite, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Invite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Invite, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Invite); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Invite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, invite
 func (_m *InviteRepository) Update(ctx context.Context, invite *domain.Invite) (*domain.Invite, error) {
 	ret := _m.Called(ctx, invite)
@@ -35,6 +35,7 @@ func (r *CustomerRepositoryImpl) FindByNameAndAccountNo(ctx context.Context, nam
 	if err := r.DB.WithContext(ctx).
 		Table("customers").
 		Where("LOWER(TRIM(customer_name)) = ? AND regexp_replace(CAST(account_no AS TEXT), '^0+', '', 'g') = ?", trimmedName, strippedAccount).
+		Where("deactivated_at IS NULL").
 		First(&customer).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil 
@@ -44,6 +45,25 @@ func (r *CustomerRepositoryImpl) FindByNameAndAccountNo(ctx context.Context, nam
 	return &customer, nil
 }
 
+func (r *CustomerRepositoryImpl) FindByAccountNo(ctx context.Context, accountNo string) (*domain.Customer, error) {
+	var customer domain.Customer
+	strippedAccount := strings.TrimLeft(accountNo, "0")
+	if strippedAccount == "" {
+		return nil, nil
+	}
+	if err := r.DB.WithContext(ctx).
+		Table("customers").
+		Where("regexp_replace(CAST(account_no AS TEXT), '^0+', '', 'g') = ?", strippedAccount).
+		Where("deactivated_at IS NULL").
+		First(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &customer, nil
+}
+
 func (r *CustomerRepositoryImpl) FindByID(ctx context.Context, id string) (*domain.Customer, error) {
 	var customer domain.Customer
 	if err := r.DB.WithContext(ctx).Table("valid_customers").Where("id = ?", id).First(&customer).Error; err != nil {
@@ -125,6 +145,7 @@ func (r *CustomerRepositoryImpl) GetTransactionsByAccount(ctx context.Context, a
 	var transactions []*domain.Transaction
 	err := r.DB.WithContext(ctx).Table("transactions").
 		Where("from_account = ? OR to_account = ?", accountNo, accountNo).
+		Order("date ASC, created_at ASC").
 		Find(&transactions).Error
 	if err != nil {
 		return nil, config.ErrInternalServer
@@ -138,4 +159,54 @@ func (r *CustomerRepositoryImpl) GetTransactionsByCustomerId(ctx context.Context
 		return nil, config.ErrInternalServer
 	}
 	return transactions, nil
-}
\ No newline at end of file
+}
+
+func (r *CustomerRepositoryImpl) CreateReview(ctx context.Context, review *domain.CustomerReview) (*domain.CustomerReview, error) {
+	if err := r.DB.WithContext(ctx).Table("customer_reviews").Create(review).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return review, nil
+}
+
+func (r *CustomerRepositoryImpl) FindReviewByID(ctx context.Context, id uint) (*domain.CustomerReview, error) {
+	var review domain.CustomerReview
+	if err := r.DB.WithContext(ctx).Table("customer_reviews").Where("id = ?", id).First(&review).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrReviewNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &review, nil
+}
+
+func (r *CustomerRepositoryImpl) ListReviews(ctx context.Context, status string) ([]*domain.CustomerReview, error) {
+	var reviews []*domain.CustomerReview
+	query := r.DB.WithContext(ctx).Table("customer_reviews").Order("created_at ASC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return reviews, nil
+}
+
+// FindPendingReviews returns the pending reviews matched to the given
+// customers row, oldest first.
+func (r *CustomerRepositoryImpl) FindPendingReviews(ctx context.Context, matchedCustomerID int) ([]*domain.CustomerReview, error) {
+	var reviews []*domain.CustomerReview
+	if err := r.DB.WithContext(ctx).Table("customer_reviews").
+		Where("matched_customer_id = ? AND status = ?", matchedCustomerID, domain.ReviewStatusPending).
+		Order("created_at ASC").
+		Find(&reviews).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return reviews, nil
+}
+
+func (r *CustomerRepositoryImpl) UpdateReview(ctx context.Context, review *domain.CustomerReview) (*domain.CustomerReview, error) {
+	if err := r.DB.WithContext(ctx).Table("customer_reviews").Save(review).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return review, nil
+}
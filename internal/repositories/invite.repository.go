@@ -4,10 +4,15 @@ import (
 	"context"
 	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/pkg/config"
+	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// errInviteTaken rolls back Accept when the invite was used in the meantime.
+var errInviteTaken = errors.New("invite already used")
+
 type InviteRepositoryImpl struct {
 	DB *gorm.DB
 }
@@ -35,10 +40,68 @@ func (r *InviteRepositoryImpl) FindByToken(ctx context.Context, token string) (*
 	return &invite, nil
 }
 
+func (r *InviteRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Invite, error) {
+	var invite domain.Invite
+	if err := r.DB.WithContext(ctx).First(&invite, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &invite, nil
+}
+
+// List returns the invites in the given status, newest first; an empty status
+// returns all of them.
+func (r *InviteRepositoryImpl) List(ctx context.Context, status string) ([]*domain.Invite, error) {
+	query := r.DB.WithContext(ctx).Order("created_at DESC, id DESC")
+	now := time.Now()
+	switch status {
+	case domain.InviteStatusUsed:
+		query = query.Where("used = ?", true)
+	case domain.InviteStatusRevoked:
+		query = query.Where("used = ? AND revoked_at IS NOT NULL", false)
+	case domain.InviteStatusExpired:
+		query = query.Where("used = ? AND revoked_at IS NULL AND expiry <= ?", false, now)
+	case domain.InviteStatusPending:
+		query = query.Where("used = ? AND revoked_at IS NULL AND expiry > ?", false, now)
+	}
+	var invites []*domain.Invite
+	if err := query.Find(&invites).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return invites, nil
+}
+
 func (r *InviteRepositoryImpl) Update(ctx context.Context, invite *domain.Invite) (*domain.Invite, error) {
 	result := r.DB.WithContext(ctx).Save(invite)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return invite, nil
-}
\ No newline at end of file
+}
+
+// Accept marks the invite used only while it is still unused and creates user
+// in the same transaction, so a failed create leaves the invite pending and
+// of two concurrent registrations only one gets the account.
+func (r *InviteRepositoryImpl) Accept(ctx context.Context, inviteID uint, user *domain.User) (bool, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table("invites").
+			Where("id = ? AND used = ?", inviteID, false).
+			Update("used", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errInviteTaken
+		}
+		return tx.Create(user).Error
+	})
+	if err == errInviteTaken {
+		return false, nil
+	}
+	if err != nil {
+		return false, config.ErrInternalServer
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type MasterCustomerRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewMasterCustomerRepository(db *gorm.DB) *MasterCustomerRepositoryImpl {
+	return &MasterCustomerRepositoryImpl{DB: db}
+}
+
+// Create inserts a record into the customers table and its history entry in
+// one transaction.
+func (r *MasterCustomerRepositoryImpl) Create(ctx context.Context, customer *domain.Customer, change *domain.CustomerChange) (*domain.Customer, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("customers").Create(customer).Error; err != nil {
+			return err
+		}
+		change.CustomerID = customer.ID
+		return tx.Table("customer_changes").Create(change).Error
+	})
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return customer, nil
+}
+
+func (r *MasterCustomerRepositoryImpl) Update(ctx context.Context, customer *domain.Customer, change *domain.CustomerChange) (*domain.Customer, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("customers").Save(customer).Error; err != nil {
+			return err
+		}
+		change.CustomerID = customer.ID
+		return tx.Table("customer_changes").Create(change).Error
+	})
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return customer, nil
+}
+
+func (r *MasterCustomerRepositoryImpl) FindByID(ctx context.Context, id int) (*domain.Customer, error) {
+	var customer domain.Customer
+	if err := r.DB.WithContext(ctx).Table("customers").Where("id = ?", id).First(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrCustomerNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &customer, nil
+}
+
+// FindByAccountNo looks up a master record, active or not, ignoring leading
+// zeros in the account number.
+func (r *MasterCustomerRepositoryImpl) FindByAccountNo(ctx context.Context, accountNo string) (*domain.Customer, error) {
+	var customer domain.Customer
+	strippedAccount := strings.TrimLeft(accountNo, "0")
+	if strippedAccount == "" {
+		return nil, nil
+	}
+	if err := r.DB.WithContext(ctx).
+		Table("customers").
+		Where("regexp_replace(CAST(account_no AS TEXT), '^0+', '', 'g') = ?", strippedAccount).
+		First(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &customer, nil
+}
+
+func (r *MasterCustomerRepositoryImpl) List(ctx context.Context, includeInactive bool) ([]*domain.Customer, error) {
+	var customers []*domain.Customer
+	query := r.DB.WithContext(ctx).Table("customers").Order("id ASC")
+	if !includeInactive {
+		query = query.Where("deactivated_at IS NULL")
+	}
+	if err := query.Find(&customers).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return customers, nil
+}
+
+func (r *MasterCustomerRepositoryImpl) History(ctx context.Context, customerID int) ([]*domain.CustomerChange, error) {
+	var changes []*domain.CustomerChange
+	if err := r.DB.WithContext(ctx).Table("customer_changes").
+		Where("customer_id = ?", customerID).
+		Order("created_at ASC, id ASC").
+		Find(&changes).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return changes, nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RepaymentOutcomeRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRepaymentOutcomeRepository(db *gorm.DB) *RepaymentOutcomeRepositoryImpl {
+	return &RepaymentOutcomeRepositoryImpl{DB: db}
+}
+
+// SaveOutcomes stores outcomes keyed by loan ID; a loan that is already
+// stored has its outcome replaced.
+func (r *RepaymentOutcomeRepositoryImpl) SaveOutcomes(ctx context.Context, outcomes []*domain.RepaymentOutcome) error {
+	err := r.DB.WithContext(ctx).Table("repayment_outcomes").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "loan_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"customer_id", "decision_date", "outcome", "updated_at"}),
+		}).
+		Create(&outcomes).Error
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *RepaymentOutcomeRepositoryImpl) ListOutcomes(ctx context.Context) ([]*domain.RepaymentOutcome, error) {
+	var outcomes []*domain.RepaymentOutcome
+	if err := r.DB.WithContext(ctx).Table("repayment_outcomes").Order("decision_date ASC, id ASC").Find(&outcomes).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return outcomes, nil
+}
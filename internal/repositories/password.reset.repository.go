@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// errResetUsed rolls back Redeem when the link was used in the meantime.
+var errResetUsed = errors.New("reset link already used")
+
+type PasswordResetRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepositoryImpl {
+	return &PasswordResetRepositoryImpl{DB: db}
+}
+
+func (r *PasswordResetRepositoryImpl) Create(ctx context.Context, reset *domain.PasswordReset) (*domain.PasswordReset, error) {
+	if err := r.DB.WithContext(ctx).Table("password_resets").Create(reset).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return reset, nil
+}
+
+func (r *PasswordResetRepositoryImpl) FindByJTI(ctx context.Context, jti string) (*domain.PasswordReset, error) {
+	var reset domain.PasswordReset
+	if err := r.DB.WithContext(ctx).Table("password_resets").Where("jti = ?", jti).First(&reset).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &reset, nil
+}
+
+// Redeem marks an unused reset link used, sets the user's password and uses
+// up the user's other unused links in one transaction, so a failure leaves
+// both the link and the password as they were. It reports false, changing
+// nothing, when the link was already used.
+func (r *PasswordResetRepositoryImpl) Redeem(ctx context.Context, jti string, userID uint, hashedPassword string) (bool, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Table("password_resets").
+			Where("jti = ? AND used_at IS NULL", jti).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errResetUsed
+		}
+		if err := tx.Table("users").Where("id = ?", userID).Updates(map[string]interface{}{"password": hashedPassword, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		return tx.Table("password_resets").
+			Where("user_id = ? AND used_at IS NULL", userID).
+			Update("used_at", now).Error
+	})
+	if err == errResetUsed {
+		return false, nil
+	}
+	if err != nil {
+		return false, config.ErrInternalServer
+	}
+	return true, nil
+}
+
+func (r *PasswordResetRepositoryImpl) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	if err := r.DB.WithContext(ctx).Table("password_resets").
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error; err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type RolePermissionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRolePermissionRepository(db *gorm.DB) *RolePermissionRepositoryImpl {
+	return &RolePermissionRepositoryImpl{DB: db}
+}
+
+func (r *RolePermissionRepositoryImpl) List(ctx context.Context) ([]*domain.RolePermission, error) {
+	var grants []*domain.RolePermission
+	if err := r.DB.WithContext(ctx).Table("role_permissions").Order("role ASC, permission ASC").Find(&grants).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return grants, nil
+}
+
+// ReplaceForRole replaces every grant of role in one transaction.
+func (r *RolePermissionRepositoryImpl) ReplaceForRole(ctx context.Context, role string, permissions []string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("role_permissions").Where("role = ?", role).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+		grants := make([]*domain.RolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			grants = append(grants, &domain.RolePermission{Role: role, Permission: permission})
+		}
+		return tx.Table("role_permissions").Create(&grants).Error
+	})
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *RolePermissionRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.DB.WithContext(ctx).Table("role_permissions").Count(&count).Error; err != nil {
+		return 0, config.ErrInternalServer
+	}
+	return count, nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type RatingRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRatingRepository(db *gorm.DB) *RatingRepositoryImpl {
+	return &RatingRepositoryImpl{DB: db}
+}
+
+func (r *RatingRepositoryImpl) SaveSnapshot(ctx context.Context, snapshot *domain.RatingSnapshot) (*domain.RatingSnapshot, error) {
+	if err := r.DB.WithContext(ctx).Table("rating_snapshots").Create(snapshot).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return snapshot, nil
+}
+
+// ListSnapshots returns a customer's stored ratings, oldest first.
+func (r *RatingRepositoryImpl) ListSnapshots(ctx context.Context, customerID string) ([]*domain.RatingSnapshot, error) {
+	var snapshots []*domain.RatingSnapshot
+	if err := r.DB.WithContext(ctx).Table("rating_snapshots").
+		Where("customer_id = ?", customerID).
+		Order("as_of ASC, id ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepositoryImpl {
+	return &RefreshTokenRepositoryImpl{DB: db}
+}
+
+func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *domain.RefreshToken) (*domain.RefreshToken, error) {
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").Create(token).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return token, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) FindByJTI(ctx context.Context, jti string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").Where("jti = ?", jti).First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &token, nil
+}
+
+// Revoke marks an active token revoked and records the token that replaced
+// it, if any. It reports false when the token was already revoked.
+func (r *RefreshTokenRepositoryImpl) Revoke(ctx context.Context, jti, replacedBy string) (bool, error) {
+	result := r.DB.WithContext(ctx).Table("refresh_tokens").
+		Where("jti = ? AND revoked_at IS NULL", jti).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": replacedBy})
+	if result.Error != nil {
+		return false, config.ErrInternalServer
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeAllForUser(ctx context.Context, userID uint) error {
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
+
+// RevokeOtherFamilies revokes every session of the user except familyID.
+func (r *RefreshTokenRepositoryImpl) RevokeOtherFamilies(ctx context.Context, userID uint, familyID string) error {
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return config.ErrInternalServer
+	}
+	return nil
+}
+
+// ListActiveForUser returns the user's unrevoked, unexpired tokens, most
+// recently used first. Rotation revokes the previous token, so there is one
+// per session.
+func (r *RefreshTokenRepositoryImpl) ListActiveForUser(ctx context.Context, userID uint) ([]*domain.RefreshToken, error) {
+	var tokens []*domain.RefreshToken
+	if err := r.DB.WithContext(ctx).Table("refresh_tokens").
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return tokens, nil
+}
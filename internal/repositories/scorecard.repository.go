@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ScorecardRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewScorecardRepository(db *gorm.DB) *ScorecardRepositoryImpl {
+	return &ScorecardRepositoryImpl{DB: db}
+}
+
+// Create stores a new scorecard version. When it is marked active, any other
+// active version for the same product is deactivated in the same transaction.
+func (r *ScorecardRepositoryImpl) Create(ctx context.Context, scorecard *domain.Scorecard) (*domain.Scorecard, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if scorecard.Active {
+			if err := deactivateScorecards(tx, scorecard.ProductName); err != nil {
+				return err
+			}
+		}
+		return tx.Table("scorecards").Create(scorecard).Error
+	})
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return scorecard, nil
+}
+
+func (r *ScorecardRepositoryImpl) FindActive(ctx context.Context, productName string) (*domain.Scorecard, error) {
+	var scorecard domain.Scorecard
+	if err := r.DB.WithContext(ctx).Table("scorecards").
+		Where("product_name = ? AND active = ?", productName, true).
+		First(&scorecard).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &scorecard, nil
+}
+
+func (r *ScorecardRepositoryImpl) FindByVersion(ctx context.Context, productName string, version string) (*domain.Scorecard, error) {
+	var scorecard domain.Scorecard
+	if err := r.DB.WithContext(ctx).Table("scorecards").
+		Where("product_name = ? AND version = ?", productName, version).
+		First(&scorecard).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrScorecardNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &scorecard, nil
+}
+
+func (r *ScorecardRepositoryImpl) List(ctx context.Context, productName string) ([]*domain.Scorecard, error) {
+	var scorecards []*domain.Scorecard
+	query := r.DB.WithContext(ctx).Table("scorecards").Order("product_name ASC, created_at ASC")
+	if productName != "" {
+		query = query.Where("product_name = ?", productName)
+	}
+	if err := query.Find(&scorecards).Error; err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return scorecards, nil
+}
+
+func (r *ScorecardRepositoryImpl) Activate(ctx context.Context, scorecard *domain.Scorecard) (*domain.Scorecard, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deactivateScorecards(tx, scorecard.ProductName); err != nil {
+			return err
+		}
+		scorecard.Active = true
+		return tx.Table("scorecards").Save(scorecard).Error
+	})
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return scorecard, nil
+}
+
+func deactivateScorecards(tx *gorm.DB, productName string) error {
+	return tx.Table("scorecards").
+		Where("product_name = ? AND active = ?", productName, true).
+		Update("active", false).Error
+}
@@ -35,10 +35,45 @@ func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*do
 	return &user, nil
 }
 
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	var user domain.User
+	if err := r.DB.WithContext(ctx).First(&user, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, config.ErrNotFound
+		}
+		return nil, config.ErrInternalServer
+	}
+	return &user, nil
+}
+
+// List returns a page of users ordered by ID and the total number of users.
+func (r *UserRepositoryImpl) List(ctx context.Context, offset, limit int) ([]*domain.User, int64, error) {
+	var total int64
+	if err := r.DB.WithContext(ctx).Model(&domain.User{}).Count(&total).Error; err != nil {
+		return nil, 0, config.ErrInternalServer
+	}
+	var users []*domain.User
+	if err := r.DB.WithContext(ctx).Order("id ASC").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, 0, config.ErrInternalServer
+	}
+	return users, total, nil
+}
+
 func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) (*domain.User, error) {
 	result := r.DB.WithContext(ctx).Save(user)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return user, nil
-}
\ No newline at end of file
+}
+
+func (r *UserRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.DB.WithContext(ctx).Delete(&domain.User{}, id)
+	if result.Error != nil {
+		return config.ErrInternalServer
+	}
+	if result.RowsAffected == 0 {
+		return config.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import "SalaryAdvance/internal/domain"
+
+type CustomerEnricherImpl struct {
+	precedence map[string]string
+}
+
+// NewCustomerEnricher builds an enricher from a field -> preferred source map.
+// Fields without an entry prefer the customers master table.
+func NewCustomerEnricher(precedence map[string]string) domain.CustomerEnricher {
+	if precedence == nil {
+		precedence = map[string]string{}
+	}
+	return &CustomerEnricherImpl{precedence: precedence}
+}
+
+// Enrich copies mobile, branch, product and balance onto target. For each field
+// the preferred source wins when it has a value, otherwise the other source is
+// used; the chosen source is recorded in target.FieldSources. A zero balance in
+// the uploaded file is treated as not supplied.
+func (e *CustomerEnricherImpl) Enrich(target *domain.Customer, submitted *domain.Customer, master *domain.Customer) {
+	if submitted == nil {
+		submitted = &domain.Customer{}
+	}
+	sources := domain.FieldSources{}
+
+	pickString := func(field string, dst *string, imported, mastered string) {
+		switch source := e.pick(field, imported != "", mastered != ""); source {
+		case domain.FieldSourceImport:
+			*dst = imported
+			sources[field] = source
+		case domain.FieldSourceMaster:
+			*dst = mastered
+			sources[field] = source
+		}
+	}
+
+	var masterMobile, masterBranchName, masterBranchCode, masterProduct string
+	if master != nil {
+		masterMobile = master.Mobile
+		masterBranchName = master.BranchName
+		masterBranchCode = master.BranchCode
+		masterProduct = master.ProductName
+	}
+	pickString("mobile", &target.Mobile, submitted.Mobile, masterMobile)
+	pickString("branchName", &target.BranchName, submitted.BranchName, masterBranchName)
+	pickString("branchCode", &target.BranchCode, submitted.BranchCode, masterBranchCode)
+	pickString("productName", &target.ProductName, submitted.ProductName, masterProduct)
+
+	switch source := e.pick("customerBalance", submitted.CustomerBalance != 0, master != nil); source {
+	case domain.FieldSourceImport:
+		target.CustomerBalance = submitted.CustomerBalance
+		sources["customerBalance"] = source
+	case domain.FieldSourceMaster:
+		target.CustomerBalance = master.CustomerBalance
+		sources["customerBalance"] = source
+	}
+
+	if len(sources) > 0 {
+		target.FieldSources = sources
+	}
+}
+
+func (e *CustomerEnricherImpl) pick(field string, hasImport, hasMaster bool) string {
+	preferImport := e.precedence[field] == domain.FieldSourceImport
+	switch {
+	case preferImport && hasImport:
+		return domain.FieldSourceImport
+	case hasMaster:
+		return domain.FieldSourceMaster
+	case hasImport:
+		return domain.FieldSourceImport
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"SalaryAdvance/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerEnricher_Enrich(t *testing.T) {
+	master := func() *domain.Customer {
+		return &domain.Customer{
+			Mobile:          "0911000000",
+			BranchName:      "Bole",
+			BranchCode:      "BR01",
+			ProductName:     "Savings",
+			CustomerBalance: 2500,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		precedence map[string]string
+		submitted  *domain.Customer
+		master     *domain.Customer
+		expected   *domain.Customer
+	}{
+		{
+			name:       "Import-preferred field with a value comes from the import",
+			precedence: map[string]string{"mobile": domain.FieldSourceImport},
+			submitted:  &domain.Customer{Mobile: "0922000000"},
+			master:     master(),
+			expected: &domain.Customer{
+				Mobile: "0922000000", BranchName: "Bole", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 2500,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceImport,
+					"branchName":      domain.FieldSourceMaster,
+					"branchCode":      domain.FieldSourceMaster,
+					"productName":     domain.FieldSourceMaster,
+					"customerBalance": domain.FieldSourceMaster,
+				},
+			},
+		},
+		{
+			name:       "Import-preferred field left empty falls back to master",
+			precedence: map[string]string{"mobile": domain.FieldSourceImport},
+			submitted:  &domain.Customer{},
+			master:     master(),
+			expected: &domain.Customer{
+				Mobile: "0911000000", BranchName: "Bole", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 2500,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceMaster,
+					"branchName":      domain.FieldSourceMaster,
+					"branchCode":      domain.FieldSourceMaster,
+					"productName":     domain.FieldSourceMaster,
+					"customerBalance": domain.FieldSourceMaster,
+				},
+			},
+		},
+		{
+			name:      "Master-preferred field empty in master comes from the import",
+			submitted: &domain.Customer{BranchName: "Piassa", Mobile: "0922000000"},
+			master: &domain.Customer{
+				Mobile: "0911000000", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 2500,
+			},
+			expected: &domain.Customer{
+				Mobile: "0911000000", BranchName: "Piassa", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 2500,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceMaster,
+					"branchName":      domain.FieldSourceImport,
+					"branchCode":      domain.FieldSourceMaster,
+					"productName":     domain.FieldSourceMaster,
+					"customerBalance": domain.FieldSourceMaster,
+				},
+			},
+		},
+		{
+			name:       "Zero balance in the file is treated as not supplied",
+			precedence: map[string]string{"customerBalance": domain.FieldSourceImport},
+			submitted:  &domain.Customer{CustomerBalance: 0},
+			master:     master(),
+			expected: &domain.Customer{
+				Mobile: "0911000000", BranchName: "Bole", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 2500,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceMaster,
+					"branchName":      domain.FieldSourceMaster,
+					"branchCode":      domain.FieldSourceMaster,
+					"productName":     domain.FieldSourceMaster,
+					"customerBalance": domain.FieldSourceMaster,
+				},
+			},
+		},
+		{
+			name:       "Import-preferred balance in the file comes from the import",
+			precedence: map[string]string{"customerBalance": domain.FieldSourceImport},
+			submitted:  &domain.Customer{CustomerBalance: 900},
+			master:     master(),
+			expected: &domain.Customer{
+				Mobile: "0911000000", BranchName: "Bole", BranchCode: "BR01", ProductName: "Savings", CustomerBalance: 900,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceMaster,
+					"branchName":      domain.FieldSourceMaster,
+					"branchCode":      domain.FieldSourceMaster,
+					"productName":     domain.FieldSourceMaster,
+					"customerBalance": domain.FieldSourceImport,
+				},
+			},
+		},
+		{
+			name:      "Without a master record only supplied fields are copied",
+			submitted: &domain.Customer{Mobile: "0922000000", CustomerBalance: 900},
+			master:    nil,
+			expected: &domain.Customer{
+				Mobile: "0922000000", CustomerBalance: 900,
+				FieldSources: domain.FieldSources{
+					"mobile":          domain.FieldSourceImport,
+					"customerBalance": domain.FieldSourceImport,
+				},
+			},
+		},
+		{
+			name:      "Nothing to copy records no sources",
+			submitted: nil,
+			master:    nil,
+			expected:  &domain.Customer{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &domain.Customer{}
+
+			NewCustomerEnricher(tt.precedence).Enrich(target, tt.submitted, tt.master)
+
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
@@ -14,13 +14,19 @@ func NewEmailService() domain.EmailService {
 }
 
 func (s *EmailServiceImpl) SendInvite(toEmail, link string) error {
+	return send(toEmail, fmt.Sprintf("Subject: Invitation to Join\n\nClick the link to register: %s", link))
+}
+
+func (s *EmailServiceImpl) SendPasswordReset(toEmail, link string) error {
+	return send(toEmail, fmt.Sprintf("Subject: Reset your password\n\nClick the link to choose a new password: %s\n\nIf you did not ask to reset your password, ignore this email.", link))
+}
+
+func send(toEmail, message string) error {
 	from := os.Getenv("EMAIL_FROM")
 	password := os.Getenv("EMAIL_PASSWORD")
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 
-	message := []byte(fmt.Sprintf("Subject: Invitation to Join\n\nClick the link to register: %s", link))
-
 	auth := smtp.PlainAuth("", from, password, smtpHost)
-	return smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{toEmail}, message)
-}
\ No newline at end of file
+	return smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{toEmail}, []byte(message))
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"SalaryAdvance/internal/domain"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is an RSA key pair identified by the kid header of the tokens it
+// signs. Once a newer key takes over signing, the key keeps verifying tokens
+// until retiresAt so tokens issued just before the rotation stay valid.
+type signingKey struct {
+	id         string
+	privateKey *rsa.PrivateKey
+	createdAt  time.Time
+	retiresAt  time.Time
+}
+
+func newSigningKey(privateKey *rsa.PrivateKey, createdAt time.Time) *signingKey {
+	return &signingKey{id: keyID(&privateKey.PublicKey), privateKey: privateKey, createdAt: createdAt}
+}
+
+// keyID is the RFC 7638 thumbprint of the public key, so a key gets the same
+// kid on every instance and across restarts.
+func keyID(publicKey *rsa.PublicKey) string {
+	jwk := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, encodeExponent(publicKey.E), base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()))
+	sum := sha256.Sum256([]byte(jwk))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func encodeExponent(e int) string {
+	return base64.RawURLEncoding.EncodeToString(big.NewInt(int64(e)).Bytes())
+}
+
+// keyReloadInterval bounds how often the key directory is re-read, so tokens
+// with made-up kids cannot force a directory read on every request.
+const keyReloadInterval = 30 * time.Second
+
+// keySet holds the key that signs new tokens and the retired keys still
+// accepted for verification. When dir is set, it is the source of truth:
+// rotated keys are written there and the directory is re-read, so instances
+// sharing it accept and publish each other's keys and keep them across
+// restarts.
+type keySet struct {
+	mu       sync.RWMutex
+	current  *signingKey
+	keys     map[string]*signingKey
+	dir      string
+	overlap  time.Duration
+	loadedAt time.Time
+}
+
+func newKeySet(dir string, overlap time.Duration, keys ...*signingKey) *keySet {
+	set := &keySet{dir: dir, overlap: overlap, loadedAt: time.Now()}
+	set.install(keys, time.Now())
+	return set
+}
+
+// install replaces the keys with keys: every key but the newest retires
+// overlap after its successor was created. The caller holds the
+// write lock or owns the set.
+func (s *keySet) install(keys []*signingKey, now time.Time) {
+	retire(keys, s.overlap)
+	s.keys = make(map[string]*signingKey, len(keys))
+	for _, key := range keys {
+		s.keys[key.id] = key
+		s.current = key
+	}
+	s.pruneLocked(now)
+}
+
+// retire sorts keys oldest first and retires every key but the newest overlap
+// after its successor was created.
+func retire(keys []*signingKey, overlap time.Duration) {
+	sort.Slice(keys, func(i, j int) bool { return keys[i].createdAt.Before(keys[j].createdAt) })
+	for i := 0; i < len(keys)-1; i++ {
+		keys[i].retiresAt = keys[i+1].createdAt.Add(overlap)
+	}
+}
+
+// reload re-reads the key directory, picking up keys rotated by other
+// instances. It does nothing without a directory, or when the directory was
+// read less than keyReloadInterval ago unless force is set. A failed read
+// keeps the keys already loaded.
+func (s *keySet) reload(force bool) {
+	if s.dir == "" {
+		return
+	}
+	s.mu.RLock()
+	fresh := time.Since(s.loadedAt) < keyReloadInterval
+	s.mu.RUnlock()
+	if fresh && !force {
+		return
+	}
+
+	now := time.Now()
+	keys, err := loadKeyDir(s.dir, s.overlap, now)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = now
+	if err != nil || len(keys) == 0 {
+		fmt.Printf("Failed to reload keys from %s: %v\n", s.dir, err)
+		return
+	}
+	s.install(keys, now)
+}
+
+// signer returns the key that signs new tokens. With a key directory, that is
+// the newest key that has been there for keyReloadInterval, so every instance
+// sharing the directory can load it before tokens signed with it arrive; when
+// every key is newer than that, the oldest signs.
+func (s *keySet) signer() *signingKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.dir == "" {
+		return s.current
+	}
+	publishedBy := time.Now().Add(-keyReloadInterval)
+	var signer, oldest *signingKey
+	for _, key := range s.keys {
+		if oldest == nil || key.createdAt.Before(oldest.createdAt) {
+			oldest = key
+		}
+		if key.createdAt.After(publishedBy) {
+			continue
+		}
+		if signer == nil || key.createdAt.After(signer.createdAt) {
+			signer = key
+		}
+	}
+	if signer == nil {
+		return oldest
+	}
+	return signer
+}
+
+// newest returns the most recently created key, which decides when the next
+// rotation is due.
+func (s *keySet) newest() *signingKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// publicKey returns the verification key for kid, re-reading the key
+// directory when kid is unknown in case another instance rotated. Tokens
+// without a kid were issued before key IDs were introduced and are checked
+// against the signer.
+func (s *keySet) publicKey(kid string) (*rsa.PublicKey, error) {
+	if kid == "" {
+		return &s.signer().privateKey.PublicKey, nil
+	}
+	if key := s.lookup(kid); key != nil {
+		return key, nil
+	}
+	s.reload(false)
+	if key := s.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (s *keySet) lookup(kid string) *rsa.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+	if !ok || (!key.retiresAt.IsZero() && time.Now().After(key.retiresAt)) {
+		return nil
+	}
+	return &key.privateKey.PublicKey
+}
+
+// rotate generates a new signing key, saves it to the key directory and
+// retires the current one after the overlap window.
+func (s *keySet) rotate() error {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	key := newSigningKey(privateKey, time.Now())
+	if err := saveKey(s.dir, key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current.retiresAt = key.createdAt.Add(s.overlap)
+	s.keys[key.id] = key
+	s.current = key
+	s.pruneLocked(key.createdAt)
+	return nil
+}
+
+// rotateEvery rotates the signing key whenever it is older than interval. The
+// key directory is re-read first, so when another instance sharing it already
+// rotated, its key is adopted instead. It never returns.
+func (s *keySet) rotateEvery(interval time.Duration) {
+	for {
+		time.Sleep(time.Until(s.newest().createdAt.Add(interval)))
+		s.reload(true)
+		if time.Since(s.newest().createdAt) < interval {
+			continue
+		}
+		if err := s.rotate(); err != nil {
+			fmt.Printf("Failed to rotate signing key: %v\n", err)
+			time.Sleep(time.Minute)
+			continue
+		}
+		fmt.Printf("Rotated signing key, new kid %s\n", s.newest().id)
+	}
+}
+
+func (s *keySet) pruneLocked(now time.Time) {
+	for id, key := range s.keys {
+		if !key.retiresAt.IsZero() && now.After(key.retiresAt) {
+			delete(s.keys, id)
+		}
+	}
+}
+
+// jwks publishes the public half of every key still accepted, the signer
+// first, including keys other instances added to the key directory.
+func (s *keySet) jwks() *domain.JWKS {
+	s.reload(false)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]*signingKey, 0, len(s.keys))
+	now := time.Now()
+	for _, key := range s.keys {
+		if key.retiresAt.IsZero() || now.Before(key.retiresAt) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].createdAt.After(keys[j].createdAt) })
+
+	jwks := &domain.JWKS{Keys: make([]domain.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		publicKey := key.privateKey.PublicKey
+		jwks.Keys = append(jwks.Keys, domain.JSONWebKey{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Kid: key.id,
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   encodeExponent(publicKey.E),
+		})
+	}
+	return jwks
+}
+
+// keyCreatedHeader is the PEM header holding a key's creation time, which
+// decides which key signs and when older keys retire. It is kept in the file
+// rather than taken from the file's modification time, so copying or
+// restoring the directory does not change either.
+const keyCreatedHeader = "Created"
+
+// loadKeyDir reads every *.pem private key in dir. Files of keys retired as of
+// now are deleted instead of loaded, so the directory does not keep old
+// private keys around. A key without a creation time is treated as the oldest.
+func loadKeyDir(dir string, overlap time.Duration, now time.Time) ([]*signingKey, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*signingKey
+	paths := make(map[*signingKey]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pem") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			// Retired and deleted by another instance since the listing.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseKeyFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+		paths[key] = path
+	}
+
+	retire(keys, overlap)
+	active := keys[:0]
+	for _, key := range keys {
+		if key.retiresAt.IsZero() || now.Before(key.retiresAt) {
+			active = append(active, key)
+			continue
+		}
+		if err := os.Remove(paths[key]); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Failed to delete retired key %s: %v\n", paths[key], err)
+		}
+	}
+	return active, nil
+}
+
+func parseKeyFile(data []byte) (*signingKey, error) {
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	var createdAt time.Time
+	if block, _ := pem.Decode(data); block != nil && block.Headers[keyCreatedHeader] != "" {
+		createdAt, err = time.Parse(time.RFC3339Nano, block.Headers[keyCreatedHeader])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header: %w", keyCreatedHeader, err)
+		}
+	}
+	return newSigningKey(privateKey, createdAt), nil
+}
+
+// saveKey writes key to dir through a temporary file renamed into place, so
+// instances reading the directory never see a partly written key.
+func saveKey(dir string, key *signingKey) error {
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:    "RSA PRIVATE KEY",
+		Headers: map[string]string{keyCreatedHeader: key.createdAt.UTC().Format(time.RFC3339Nano)},
+		Bytes:   x509.MarshalPKCS1PrivateKey(key.privateKey),
+	})
+	tmp, err := os.CreateTemp(dir, ".key-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, key.id+".pem"))
+}
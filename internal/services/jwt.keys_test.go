@@ -0,0 +1,286 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSigningKey(t *testing.T, createdAt time.Time) *signingKey {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return newSigningKey(privateKey, createdAt)
+}
+
+func TestKeySet_Install(t *testing.T) {
+	now := time.Now()
+	overlap := time.Hour
+	oldest := testSigningKey(t, now.Add(-5*time.Hour))
+	older := testSigningKey(t, now.Add(-2*time.Hour))
+	middle := testSigningKey(t, now.Add(-30*time.Minute))
+	newest := testSigningKey(t, now.Add(-time.Minute))
+
+	tests := []struct {
+		name        string
+		keys        []*signingKey
+		current     *signingKey
+		retiresAt   map[*signingKey]time.Time
+		pruned      []*signingKey
+		expectedLen int
+	}{
+		{
+			name:        "Single key never retires",
+			keys:        []*signingKey{newest},
+			current:     newest,
+			retiresAt:   map[*signingKey]time.Time{newest: {}},
+			expectedLen: 1,
+		},
+		{
+			name:    "Keys are ordered by creation time regardless of input order",
+			keys:    []*signingKey{newest, middle},
+			current: newest,
+			retiresAt: map[*signingKey]time.Time{
+				middle: newest.createdAt.Add(overlap),
+				newest: {},
+			},
+			expectedLen: 2,
+		},
+		{
+			name:    "Keys retired before now are pruned",
+			keys:    []*signingKey{middle, oldest, newest, older},
+			current: newest,
+			retiresAt: map[*signingKey]time.Time{
+				older:  middle.createdAt.Add(overlap),
+				middle: newest.createdAt.Add(overlap),
+				newest: {},
+			},
+			pruned:      []*signingKey{oldest},
+			expectedLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &keySet{overlap: overlap}
+			set.install(tt.keys, now)
+
+			assert.Same(t, tt.current, set.current)
+			assert.Len(t, set.keys, tt.expectedLen)
+			for key, retiresAt := range tt.retiresAt {
+				assert.Same(t, key, set.keys[key.id])
+				assert.Equal(t, retiresAt, key.retiresAt)
+			}
+			for _, key := range tt.pruned {
+				assert.NotContains(t, set.keys, key.id)
+			}
+		})
+	}
+}
+
+func TestKeySet_Signer(t *testing.T) {
+	now := time.Now()
+	published := testSigningKey(t, now.Add(-time.Hour))
+	recent := testSigningKey(t, now.Add(-2*keyReloadInterval))
+	tooNew := testSigningKey(t, now.Add(-keyReloadInterval/3))
+	newest := testSigningKey(t, now)
+
+	tests := []struct {
+		name     string
+		dir      string
+		keys     []*signingKey
+		expected *signingKey
+	}{
+		{
+			name:     "Without a key directory the newest key signs at once",
+			keys:     []*signingKey{published, newest},
+			expected: newest,
+		},
+		{
+			name:     "Newest key signs once it has been published",
+			dir:      "keys",
+			keys:     []*signingKey{published, recent},
+			expected: recent,
+		},
+		{
+			name:     "Key younger than the reload interval does not sign yet",
+			dir:      "keys",
+			keys:     []*signingKey{published, recent, tooNew},
+			expected: recent,
+		},
+		{
+			name:     "Oldest key signs when every key is too new",
+			dir:      "keys",
+			keys:     []*signingKey{newest, tooNew},
+			expected: tooNew,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &keySet{dir: tt.dir, overlap: 24 * time.Hour}
+			set.install(append([]*signingKey{}, tt.keys...), now)
+
+			assert.Same(t, tt.expected, set.signer())
+		})
+	}
+}
+
+func TestKeySet_PublicKey(t *testing.T) {
+	now := time.Now()
+	retired := testSigningKey(t, now.Add(-5*time.Hour))
+	previous := testSigningKey(t, now.Add(-3*time.Hour))
+	current := testSigningKey(t, now.Add(-30*time.Minute))
+
+	set := &keySet{overlap: time.Hour}
+	// Installing as of the rotation keeps the retired key in the map, so the
+	// lookup itself has to reject it.
+	set.install([]*signingKey{retired, previous, current}, previous.createdAt)
+
+	tests := []struct {
+		name        string
+		kid         string
+		expected    *rsa.PublicKey
+		expectedErr bool
+	}{
+		{name: "Current key", kid: current.id, expected: &current.privateKey.PublicKey},
+		{name: "Key within the overlap window", kid: previous.id, expected: &previous.privateKey.PublicKey},
+		{name: "Retired key is rejected", kid: retired.id, expectedErr: true},
+		{name: "Unknown key is rejected", kid: "unknown", expectedErr: true},
+		{name: "Token without a kid is checked against the signer", kid: "", expected: &current.privateKey.PublicKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey, err := set.publicKey(tt.kid)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, publicKey)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.expected.Equal(publicKey))
+			}
+		})
+	}
+}
+
+func TestKeySet_RotateAndReload(t *testing.T) {
+	dir := t.TempDir()
+	overlap := time.Hour
+	initial := testSigningKey(t, time.Now().Add(-time.Hour))
+	if !assert.NoError(t, saveKey(dir, initial)) {
+		return
+	}
+
+	rotating := newKeySet(dir, overlap, initial)
+	// A second instance sharing the directory, loaded before the rotation.
+	loaded, err := loadKeyDir(dir, overlap, time.Now())
+	if !assert.NoError(t, err) || !assert.Len(t, loaded, 1) {
+		return
+	}
+	other := newKeySet(dir, overlap, loaded...)
+
+	if !assert.NoError(t, rotating.rotate()) {
+		return
+	}
+	rotated := rotating.newest()
+	assert.NotEqual(t, initial.id, rotated.id)
+	assert.Equal(t, rotated.createdAt.Add(overlap), initial.retiresAt)
+
+	_, err = other.publicKey(rotated.id)
+	assert.Error(t, err, "the directory was read less than keyReloadInterval ago")
+
+	other.reload(true)
+	publicKey, err := other.publicKey(rotated.id)
+	assert.NoError(t, err)
+	assert.True(t, rotated.privateKey.PublicKey.Equal(publicKey))
+	assert.Equal(t, rotated.id, other.newest().id)
+	assert.True(t, rotated.createdAt.Equal(other.newest().createdAt))
+	assert.True(t, rotated.createdAt.Add(overlap).Equal(other.keys[initial.id].retiresAt))
+	assert.Len(t, other.jwks().Keys, 2)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, initial.id+".pem"),
+		filepath.Join(dir, rotated.id+".pem"),
+	}, files, "saving leaves no temporary files behind")
+}
+
+func TestLoadKeyDir(t *testing.T) {
+	now := time.Now()
+	overlap := time.Hour
+	retired := testSigningKey(t, now.Add(-5*time.Hour))
+	previous := testSigningKey(t, now.Add(-3*time.Hour))
+	current := testSigningKey(t, now.Add(-30*time.Minute))
+
+	tests := []struct {
+		name     string
+		saved    []*signingKey
+		prepare  func(t *testing.T, dir string)
+		expected []*signingKey
+		deleted  []*signingKey
+	}{
+		{
+			name:  "Creation time comes from the key, not the file",
+			saved: []*signingKey{previous, current},
+			prepare: func(t *testing.T, dir string) {
+				// Touching the older key must not make it the signer.
+				path := filepath.Join(dir, previous.id+".pem")
+				assert.NoError(t, os.Chtimes(path, now, now))
+			},
+			expected: []*signingKey{previous, current},
+		},
+		{
+			name:     "Retired keys are deleted instead of loaded",
+			saved:    []*signingKey{retired, previous, current},
+			expected: []*signingKey{previous, current},
+			deleted:  []*signingKey{retired},
+		},
+		{
+			name:  "Temporary files of keys being written are ignored",
+			saved: []*signingKey{current},
+			prepare: func(t *testing.T, dir string) {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, ".key-123.tmp"), []byte("-----BEGIN RSA"), 0o600))
+			},
+			expected: []*signingKey{current},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, key := range tt.saved {
+				if !assert.NoError(t, saveKey(dir, key)) {
+					return
+				}
+			}
+			if tt.prepare != nil {
+				tt.prepare(t, dir)
+			}
+
+			keys, err := loadKeyDir(dir, overlap, now)
+
+			assert.NoError(t, err)
+			if !assert.Len(t, keys, len(tt.expected)) {
+				return
+			}
+			for i, key := range keys {
+				assert.Equal(t, tt.expected[i].id, key.id)
+				assert.True(t, tt.expected[i].createdAt.Equal(key.createdAt))
+			}
+			for _, key := range tt.deleted {
+				_, err := os.Stat(filepath.Join(dir, key.id+".pem"))
+				assert.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
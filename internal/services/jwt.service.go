@@ -6,125 +6,269 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const inviteTTL = 24 * time.Hour
+
 type JWTServiceImpl struct {
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
-	issuer     string
-	accessTTL  time.Duration
-	refreshTTL time.Duration
+	keys        *keySet
+	issuer      string
+	audienceAPI string
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	resetTTL    time.Duration
 }
 
+// NewJWTService loads the signing keys from JWT_KEY_DIR when it is set, else
+// from the configured PEM or PEM file, else generates a throwaway key. With
+// JWT_KEY_ROTATION_HOURS set, a new signing key is generated on that schedule;
+// retired keys keep verifying tokens for the overlap window, which defaults to
+// the refresh token lifetime. Rotation requires JWT_KEY_DIR, the only place
+// rotated keys are shared between instances and kept across restarts.
 func NewJWTService(cfg *config.Config) domain.JWTService {
-	var privateKey *rsa.PrivateKey
-	var publicKey *rsa.PublicKey
-	var err error
-
-	
-	if cfg.PrivateKeyPEM != "" && cfg.PublicKeyPEM != "" {
-		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.PrivateKeyPEM))
-		if err != nil {
-			fmt.Printf("Failed to parse private key: %v\n", err)
-			panic(err)
+	if cfg.KeyRotationHours > 0 && cfg.KeyDir == "" {
+		panic(fmt.Errorf("JWT_KEY_ROTATION_HOURS requires JWT_KEY_DIR"))
+	}
+	refreshTTL := time.Duration(cfg.RefreshTTLMin) * time.Minute
+	overlap := time.Duration(cfg.KeyOverlapHours) * time.Hour
+	if overlap == 0 {
+		overlap = refreshTTL
+		if overlap < inviteTTL {
+			overlap = inviteTTL
 		}
-		publicKey, err = jwt.ParseRSAPublicKeyFromPEM([]byte(cfg.PublicKeyPEM))
+	}
+
+	var keys []*signingKey
+	var err error
+	switch {
+	case cfg.KeyDir != "":
+		keys, err = loadKeyDir(cfg.KeyDir, overlap, time.Now())
 		if err != nil {
-			fmt.Printf("Failed to parse public key: %v\n", err)
+			fmt.Printf("Failed to load keys from %s: %v\n", cfg.KeyDir, err)
 			panic(err)
 		}
-	} else {
-		
-		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case cfg.PrivateKeyPEM != "" || cfg.PrivateKeyFile != "":
+		keys = []*signingKey{loadConfiguredKey(cfg)}
+	}
+
+	if len(keys) == 0 {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 		if err != nil {
 			fmt.Printf("Failed to generate private key: %v\n", err)
 			panic(err)
 		}
-		publicKey = &privateKey.PublicKey
-		fmt.Println("Generated new RSA key pair (development mode)")
+		key := newSigningKey(privateKey, time.Now())
+		if cfg.KeyDir != "" {
+			if err := saveKey(cfg.KeyDir, key); err != nil {
+				fmt.Printf("Failed to save generated key: %v\n", err)
+				panic(err)
+			}
+			fmt.Printf("Generated new RSA key pair in %s\n", cfg.KeyDir)
+		} else {
+			fmt.Println("Generated new RSA key pair (development mode)")
+		}
+		keys = append(keys, key)
+	}
+
+	set := newKeySet(cfg.KeyDir, overlap, keys...)
+	if cfg.KeyRotationHours > 0 {
+		go set.rotateEvery(time.Duration(cfg.KeyRotationHours) * time.Hour)
 	}
 
 	return &JWTServiceImpl{
-		privateKey: privateKey,
-		publicKey:  publicKey,
-		issuer:     cfg.Issuer,
-		accessTTL:  time.Duration(cfg.AccessTTLMin) * time.Minute,
-		refreshTTL: time.Duration(cfg.RefreshTTLMin) * time.Minute,
+		keys:        set,
+		issuer:      cfg.Issuer,
+		audienceAPI: cfg.Audience,
+		accessTTL:   time.Duration(cfg.AccessTTLMin) * time.Minute,
+		refreshTTL:  refreshTTL,
+		resetTTL:    time.Duration(cfg.PasswordResetTTLMin) * time.Minute,
 	}
 }
 
-func (s *JWTServiceImpl) GenerateAccessToken(user *domain.User) (string, error) {
-	claims := jwt.MapClaims{
-		"id":    user.ID,
-		"email": user.Email,
-		"role":  user.Role,
-		"iss":   s.issuer,
-		"exp":   time.Now().Add(s.accessTTL).Unix(),
+// loadConfiguredKey reads the key pair from JWT_PRIVATE_KEY or
+// JWT_PRIVATE_KEY_FILE. The public key is optional but must match when given.
+func loadConfiguredKey(cfg *config.Config) *signingKey {
+	privatePEM, err := pemFromConfig(cfg.PrivateKeyPEM, cfg.PrivateKeyFile)
+	if err != nil {
+		fmt.Printf("Failed to read private key: %v\n", err)
+		panic(err)
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	signed, err := token.SignedString(s.privateKey)
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
 	if err != nil {
-		fmt.Printf("Failed to sign access token: %v\n", err)
-		return "", err
+		fmt.Printf("Failed to parse private key: %v\n", err)
+		panic(err)
 	}
-	return signed, nil
-}
 
-func (s *JWTServiceImpl) GenerateRefreshToken(user *domain.User) (string, error) {
-	claims := jwt.MapClaims{
-		"id":  user.ID,
-		"iss": s.issuer,
-		"exp": time.Now().Add(s.refreshTTL).Unix(),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	signed, err := token.SignedString(s.privateKey)
+	publicPEM, err := pemFromConfig(cfg.PublicKeyPEM, cfg.PublicKeyFile)
 	if err != nil {
-		fmt.Printf("Failed to sign refresh token: %v\n", err)
-		return "", err
+		fmt.Printf("Failed to read public key: %v\n", err)
+		panic(err)
+	}
+	if len(publicPEM) > 0 {
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			fmt.Printf("Failed to parse public key: %v\n", err)
+			panic(err)
+		}
+		if !publicKey.Equal(&privateKey.PublicKey) {
+			panic(fmt.Errorf("public key does not match private key"))
+		}
+	}
+	return newSigningKey(privateKey, time.Now())
+}
+
+func pemFromConfig(value, file string) ([]byte, error) {
+	if value != "" || file == "" {
+		return []byte(value), nil
 	}
-	return signed, nil
+	return os.ReadFile(file)
+}
+
+// JWKS returns the public keys that verify this service's tokens.
+func (s *JWTServiceImpl) JWKS() *domain.JWKS {
+	return s.keys.jwks()
 }
 
-func (s *JWTServiceImpl) GenerateInviteToken(email string, adminID uint) (string, error) {
-	claims := jwt.MapClaims{
-		"email":      email,
-		"invited_by": adminID,
-		"iss":        s.issuer,
-		"exp":        time.Now().Add(24 * time.Hour).Unix(),
+// tokenClaims is the payload of every token this service signs. Typ names the
+// token's purpose and is checked together with the audience on validation.
+type tokenClaims struct {
+	UserID    uint   `json:"id,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role,omitempty"`
+	InvitedBy uint   `json:"invited_by,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	Type      string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// audience returns the aud claim of a token type. Access tokens are meant for
+// the API and any service verifying them; the other types are only ever
+// redeemed by this service, so they are addressed to the issuer itself.
+func (s *JWTServiceImpl) audience(tokenType string) string {
+	if tokenType == domain.TokenTypeAccess {
+		return s.audienceAPI
 	}
+	return s.issuer
+}
+
+func (s *JWTServiceImpl) sign(claims *tokenClaims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims.Issuer = s.issuer
+	claims.Audience = jwt.ClaimStrings{s.audience(claims.Type)}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	key := s.keys.signer()
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	signed, err := token.SignedString(s.privateKey)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.privateKey)
 	if err != nil {
-		fmt.Printf("Failed to sign invite token: %v\n", err)
-		return "", err
+		fmt.Printf("Failed to sign %s token: %v\n", claims.Type, err)
+		return "", time.Time{}, err
 	}
-	return signed, nil
+	return signed, expiresAt, nil
 }
 
-func (s *JWTServiceImpl) ValidateToken(tokenString string) (map[string]interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			fmt.Printf("Unexpected signing method: %v\n", token.Header["alg"])
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.publicKey, nil
-	})
+// GenerateAccessToken signs an access token for user in the login session
+// sessionID, the family of the refresh token issued alongside it.
+func (s *JWTServiceImpl) GenerateAccessToken(user *domain.User, sessionID string) (string, error) {
+	signed, _, err := s.sign(&tokenClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
+		Type:      domain.TokenTypeAccess,
+	}, s.accessTTL)
+	return signed, err
+}
+
+// GenerateRefreshToken signs a refresh token identified by jti and returns it
+// with its expiry, so the caller can persist it.
+func (s *JWTServiceImpl) GenerateRefreshToken(user *domain.User, jti string) (string, time.Time, error) {
+	return s.sign(&tokenClaims{
+		UserID:           user.ID,
+		Type:             domain.TokenTypeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti},
+	}, s.refreshTTL)
+}
+
+func (s *JWTServiceImpl) GenerateInviteToken(email, role string, adminID uint) (string, error) {
+	signed, _, err := s.sign(&tokenClaims{
+		Email:     email,
+		Role:      role,
+		InvitedBy: adminID,
+		Type:      domain.TokenTypeInvite,
+	}, inviteTTL)
+	return signed, err
+}
+
+// GeneratePasswordResetToken signs a password reset token identified by jti
+// and returns it with its expiry, so the caller can persist it.
+func (s *JWTServiceImpl) GeneratePasswordResetToken(user *domain.User, jti string) (string, time.Time, error) {
+	return s.sign(&tokenClaims{
+		UserID:           user.ID,
+		Type:             domain.TokenTypePasswordReset,
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti},
+	}, s.resetTTL)
+}
+
+func (s *JWTServiceImpl) ValidateAccessToken(tokenString string) (*domain.TokenClaims, error) {
+	return s.validate(tokenString, domain.TokenTypeAccess)
+}
+
+func (s *JWTServiceImpl) ValidateRefreshToken(tokenString string) (*domain.TokenClaims, error) {
+	return s.validate(tokenString, domain.TokenTypeRefresh)
+}
+
+func (s *JWTServiceImpl) ValidateInviteToken(tokenString string) (*domain.TokenClaims, error) {
+	return s.validate(tokenString, domain.TokenTypeInvite)
+}
+
+func (s *JWTServiceImpl) ValidatePasswordResetToken(tokenString string) (*domain.TokenClaims, error) {
+	return s.validate(tokenString, domain.TokenTypePasswordReset)
+}
+
+// validate checks the signature, issuer, expiry and the audience of
+// tokenType, then rejects tokens issued for any other purpose.
+func (s *JWTServiceImpl) validate(tokenString, tokenType string) (*domain.TokenClaims, error) {
+	claims := &tokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.keys.publicKey(kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(s.audience(tokenType)),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		fmt.Printf("Token parsing failed: %v\n", err)
 		return nil, err
 	}
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
+	if !token.Valid {
 		fmt.Println("Invalid token claims or token not valid")
 		return nil, fmt.Errorf("invalid token")
 	}
+	if claims.Type != tokenType {
+		fmt.Printf("Invalid token type: got %q, expected %q\n", claims.Type, tokenType)
+		return nil, fmt.Errorf("invalid token type")
+	}
 
-	if claims["iss"] != s.issuer {
-		fmt.Printf("Invalid issuer: got %v, expected %v\n", claims["iss"], s.issuer)
-		return nil, fmt.Errorf("invalid issuer")
+	result := &domain.TokenClaims{
+		UserID:    claims.UserID,
+		Email:     claims.Email,
+		Role:      claims.Role,
+		InvitedBy: claims.InvitedBy,
+		Type:      claims.Type,
+		JTI:       claims.ID,
+		SessionID: claims.SessionID,
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
 	}
-	return claims, nil
+	return result, nil
 }
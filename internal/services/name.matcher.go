@@ -0,0 +1,92 @@
+package services
+
+import (
+	"SalaryAdvance/internal/domain"
+	"math"
+	"sort"
+	"strings"
+)
+
+type NameMatcherImpl struct {
+	autoThreshold   float64
+	reviewThreshold float64
+}
+
+// NewNameMatcher returns a matcher that accepts names scoring at least
+// autoThreshold and queues those between reviewThreshold and autoThreshold.
+func NewNameMatcher(autoThreshold, reviewThreshold float64) domain.NameMatcher {
+	if reviewThreshold > autoThreshold {
+		reviewThreshold = autoThreshold
+	}
+	return &NameMatcherImpl{autoThreshold: autoThreshold, reviewThreshold: reviewThreshold}
+}
+
+func (m *NameMatcherImpl) Match(submitted, reference string) (float64, domain.MatchDecision) {
+	score := NameSimilarity(submitted, reference)
+	switch {
+	case score >= m.autoThreshold:
+		return score, domain.MatchAccepted
+	case score >= m.reviewThreshold:
+		return score, domain.MatchReview
+	default:
+		return score, domain.MatchRejected
+	}
+}
+
+// NameSimilarity scores two names between 0 and 1. It takes the better of the
+// edit-distance similarity of the normalized names and of their sorted tokens,
+// so both typos and reordered given/family names score high.
+func NameSimilarity(a, b string) float64 {
+	na, nb := normalizeName(a), normalizeName(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 1
+	}
+	direct := editSimilarity(na, nb)
+	sorted := editSimilarity(sortTokens(na), sortTokens(nb))
+	return math.Round(math.Max(direct, sorted)*10000) / 10000
+}
+
+func normalizeName(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func sortTokens(s string) string {
+	tokens := strings.Fields(s)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"SalaryAdvance/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name      string
+		submitted string
+		reference string
+		expected  float64
+	}{
+		{name: "Identical names", submitted: "Abebe Kebede", reference: "Abebe Kebede", expected: 1},
+		{name: "Case and spacing are ignored", submitted: " abebe   KEBEDE ", reference: "Abebe Kebede", expected: 1},
+		{name: "Typo and doubled space", submitted: "Abebe  Kebde", reference: "Abebe Kebede", expected: 0.9167},
+		{name: "Given and family names swapped", submitted: "Kebede Abebe", reference: "Abebe Kebede", expected: 1},
+		{name: "Different given name", submitted: "Alemu Kebede", reference: "Abebe Kebede", expected: 0.75},
+		{name: "Mostly different given name", submitted: "Bekele Kebede", reference: "Abebe Kebede", expected: 0.6923},
+		{name: "Empty submitted name", submitted: "", reference: "Abebe Kebede", expected: 0},
+		{name: "Blank reference name", submitted: "Abebe Kebede", reference: "   ", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NameSimilarity(tt.submitted, tt.reference))
+		})
+	}
+}
+
+func TestNameMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name             string
+		autoThreshold    float64
+		reviewThreshold  float64
+		submitted        string
+		expectedDecision domain.MatchDecision
+	}{
+		{name: "Typo is accepted at the default thresholds", autoThreshold: 0.9, reviewThreshold: 0.75, submitted: "Abebe  Kebde", expectedDecision: domain.MatchAccepted},
+		{name: "Swapped names are accepted", autoThreshold: 0.9, reviewThreshold: 0.75, submitted: "Kebede Abebe", expectedDecision: domain.MatchAccepted},
+		{name: "Score at the review threshold is queued", autoThreshold: 0.9, reviewThreshold: 0.75, submitted: "Alemu Kebede", expectedDecision: domain.MatchReview},
+		{name: "Score just under the review threshold is rejected", autoThreshold: 0.9, reviewThreshold: 0.75, submitted: "Bekele Kebede", expectedDecision: domain.MatchRejected},
+		{name: "Empty name is rejected", autoThreshold: 0.9, reviewThreshold: 0.75, submitted: "", expectedDecision: domain.MatchRejected},
+		{name: "Review threshold above the auto threshold is clamped", autoThreshold: 0.8, reviewThreshold: 0.95, submitted: "Abeba Kebedu", expectedDecision: domain.MatchAccepted},
+		{name: "Clamped matcher never queues for review", autoThreshold: 0.8, reviewThreshold: 0.95, submitted: "Alemu Kebede", expectedDecision: domain.MatchRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := NewNameMatcher(tt.autoThreshold, tt.reviewThreshold)
+
+			score, decision := matcher.Match(tt.submitted, "Abebe Kebede")
+
+			assert.Equal(t, NameSimilarity(tt.submitted, "Abebe Kebede"), score)
+			assert.Equal(t, tt.expectedDecision, decision)
+		})
+	}
+}
@@ -2,21 +2,30 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"time"
 	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/internal/services"
 	"SalaryAdvance/pkg/config"
+
+	"github.com/google/uuid"
 )
 
 type AuthUseCaseImpl struct {
-	userRepo   domain.UserRepository
-	jwtService domain.JWTService
+	userRepo     domain.UserRepository
+	inviteRepo   domain.InviteRepository
+	tokenRepo    domain.RefreshTokenRepository
+	resetRepo    domain.PasswordResetRepository
+	jwtService   domain.JWTService
+	emailService domain.EmailService
 }
 
-func NewAuthUseCase(userRepo domain.UserRepository, jwtService domain.JWTService) *AuthUseCaseImpl {
-	return &AuthUseCaseImpl{userRepo: userRepo, jwtService: jwtService}
+func NewAuthUseCase(userRepo domain.UserRepository, inviteRepo domain.InviteRepository, tokenRepo domain.RefreshTokenRepository, resetRepo domain.PasswordResetRepository, jwtService domain.JWTService, emailService domain.EmailService) *AuthUseCaseImpl {
+	return &AuthUseCaseImpl{userRepo: userRepo, inviteRepo: inviteRepo, tokenRepo: tokenRepo, resetRepo: resetRepo, jwtService: jwtService, emailService: emailService}
 }
 
-func (u *AuthUseCaseImpl) Login(ctx context.Context, email, password string) (string, string, error) {
+// Login starts a new session for client.
+func (u *AuthUseCaseImpl) Login(ctx context.Context, email, password string, client domain.ClientInfo) (string, string, error) {
 	user, err := u.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return "", "", config.ErrNotFound
@@ -24,55 +33,318 @@ func (u *AuthUseCaseImpl) Login(ctx context.Context, email, password string) (st
 	if !services.CheckPasswordHash(password, user.Password) {
 		return "", "", config.ErrUnauthorized
 	}
-	accessToken, err := u.jwtService.GenerateAccessToken(user)
+	if !user.IsActive() {
+		return "", "", config.ErrUserDeactivated
+	}
+	familyID := uuid.New().String()
+	accessToken, err := u.jwtService.GenerateAccessToken(user, familyID)
 	if err != nil {
 		return "", "", config.ErrInternalServer
 	}
-	refreshToken, err := u.jwtService.GenerateRefreshToken(user)
+	refreshToken, err := u.issueRefreshToken(ctx, user, familyID, uuid.New().String(), client)
 	if err != nil {
-		return "", "", config.ErrInternalServer
+		return "", "", err
 	}
 	return accessToken, refreshToken, nil
 }
 
+// RegisterFromInvite creates the account of a pending invite, with the role the
+// admin chose, and marks the invite used in the same transaction, so each
+// invite registers at most one user. Losing a race with a concurrent
+// registration of the same invite reports the invite as already accepted.
 func (u *AuthUseCaseImpl) RegisterFromInvite(ctx context.Context, token, password string) error {
-	claims, err := u.jwtService.ValidateToken(token)
+	invite, err := pendingInvite(ctx, u.inviteRepo, u.jwtService, token)
 	if err != nil {
-		return config.ErrUnauthorized
-	}
-	email, ok := claims["email"].(string)
-	if !ok {
-		return config.ErrBadRequest
+		return err
 	}
 	hashedPassword, err := services.HashPassword(password)
 	if err != nil {
 		return config.ErrInternalServer
 	}
 	user := &domain.User{
-		Email:    email,
+		Email:    invite.Email,
 		Password: hashedPassword,
-		Role:     "uploader",
+		Role:     invite.Role,
+	}
+	accepted, err := u.inviteRepo.Accept(ctx, invite.ID, user)
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	if !accepted {
+		return config.ErrInviteAccepted
+	}
+	return nil
+}
+
+// RefreshToken rotates a refresh token: the presented token is revoked and a
+// new access and refresh token pair is issued in the same family. Presenting a
+// token that was already rotated or revoked revokes the whole family, logging
+// out both the legitimate client and whoever replayed the token. The user is
+// reloaded so the new access token carries their current role, and
+// deactivated users lose the session. The new token records client as the
+// session's last use.
+func (u *AuthUseCaseImpl) RefreshToken(ctx context.Context, refreshToken string, client domain.ClientInfo) (string, string, error) {
+	claims, err := u.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return "", "", config.ErrUnauthorized
+	}
+	stored, err := u.findRefreshToken(ctx, claims)
+	if err != nil {
+		return "", "", err
+	}
+	if stored.UserID != claims.UserID {
+		return "", "", config.ErrUnauthorized
+	}
+	if stored.RevokedAt != nil {
+		return "", "", u.revokeFamily(ctx, stored)
+	}
+
+	user, err := u.userRepo.FindByID(ctx, stored.UserID)
+	if err == config.ErrNotFound {
+		return "", "", config.ErrUnauthorized
+	}
+	if err != nil {
+		return "", "", config.ErrInternalServer
+	}
+	if !user.IsActive() {
+		if err := u.tokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return "", "", config.ErrInternalServer
+		}
+		return "", "", config.ErrUserDeactivated
+	}
+
+	accessToken, err := u.jwtService.GenerateAccessToken(user, stored.FamilyID)
+	if err != nil {
+		return "", "", config.ErrInternalServer
+	}
+	jti := uuid.New().String()
+	revoked, err := u.tokenRepo.Revoke(ctx, stored.JTI, jti)
+	if err != nil {
+		return "", "", config.ErrInternalServer
+	}
+	if !revoked {
+		// A concurrent refresh rotated the token first.
+		return "", "", u.revokeFamily(ctx, stored)
+	}
+	newRefreshToken, err := u.issueRefreshToken(ctx, user, stored.FamilyID, jti, client)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, newRefreshToken, nil
+}
+
+// Logout revokes the session the refresh token belongs to. Logging out of an
+// already revoked session succeeds.
+func (u *AuthUseCaseImpl) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := u.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return config.ErrUnauthorized
+	}
+	stored, err := u.findRefreshToken(ctx, claims)
+	if err != nil {
+		return err
+	}
+	return u.tokenRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+// ForgotPassword emails a single-use password reset link to email. Unknown
+// and deactivated accounts get no email but the same answer, so the endpoint
+// does not reveal which emails are registered; failures to issue or send the
+// link are logged rather than returned for the same reason.
+func (u *AuthUseCaseImpl) ForgotPassword(ctx context.Context, email string) error {
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err == config.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	if !user.IsActive() {
+		return nil
+	}
+	if err := u.sendPasswordReset(ctx, user); err != nil {
+		fmt.Printf("Failed to send password reset to user %d: %v\n", user.ID, err)
+	}
+	return nil
+}
+
+func (u *AuthUseCaseImpl) sendPasswordReset(ctx context.Context, user *domain.User) error {
+	jti := uuid.New().String()
+	token, expiresAt, err := u.jwtService.GeneratePasswordResetToken(user, jti)
+	if err != nil {
+		return err
+	}
+	_, err = u.resetRepo.Create(ctx, &domain.PasswordReset{
+		JTI:       jti,
+		UserID:    user.ID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("http://localhost:8080/reset-password?token=%s", token)
+	return u.emailService.SendPasswordReset(user.Email, link)
+}
+
+// ResetPassword sets a new password through a reset link, uses up the link
+// and every other outstanding link of the user, and revokes every refresh
+// token of the user, logging out all their sessions.
+func (u *AuthUseCaseImpl) ResetPassword(ctx context.Context, token, password string) error {
+	claims, err := u.jwtService.ValidatePasswordResetToken(token)
+	if err != nil || claims.JTI == "" {
+		return config.ErrResetTokenInvalid
+	}
+	reset, err := u.resetRepo.FindByJTI(ctx, claims.JTI)
+	if err == config.ErrNotFound {
+		return config.ErrResetTokenInvalid
+	}
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	if reset.UserID != claims.UserID || reset.UsedAt != nil || !reset.ExpiresAt.After(time.Now()) {
+		return config.ErrResetTokenInvalid
+	}
+	user, err := u.userRepo.FindByID(ctx, reset.UserID)
+	if err == config.ErrNotFound {
+		return config.ErrResetTokenInvalid
+	}
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	if !user.IsActive() {
+		return config.ErrUserDeactivated
+	}
+
+	hashedPassword, err := services.HashPassword(password)
+	if err != nil {
+		return config.ErrInternalServer
 	}
-	_, err = u.userRepo.Create(ctx, user)
+	redeemed, err := u.resetRepo.Redeem(ctx, reset.JTI, user.ID, hashedPassword)
 	if err != nil {
 		return config.ErrInternalServer
 	}
+	if !redeemed {
+		// A concurrent reset redeemed the link first.
+		return config.ErrResetTokenInvalid
+	}
+	if err := u.tokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return config.ErrInternalServer
+	}
 	return nil
 }
 
-func (u *AuthUseCaseImpl) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
-	claims, err := u.jwtService.ValidateToken(refreshToken)
+// ChangePassword sets a new password for a logged-in user who knows the
+// current one, voids their outstanding password reset links and logs out
+// every other session. sessionID is the session of the request; without one,
+// every session is logged out.
+func (u *AuthUseCaseImpl) ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err == config.ErrNotFound {
+		return config.ErrUnauthorized
+	}
 	if err != nil {
-		return "", config.ErrUnauthorized
+		return config.ErrInternalServer
 	}
-	userID, ok := claims["id"].(float64)
-	if !ok {
-		return "", config.ErrBadRequest
+	if !user.IsActive() {
+		return config.ErrUserDeactivated
 	}
-	user := &domain.User{ID: uint(userID)}
-	accessToken, err := u.jwtService.GenerateAccessToken(user)
+	if !services.CheckPasswordHash(currentPassword, user.Password) {
+		return config.ErrIncorrectPassword
+	}
+	hashedPassword, err := services.HashPassword(newPassword)
+	if err != nil {
+		return config.ErrInternalServer
+	}
+	user.Password = hashedPassword
+	if _, err := u.userRepo.Update(ctx, user); err != nil {
+		return config.ErrInternalServer
+	}
+	if err := u.resetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return u.tokenRepo.RevokeAllForUser(ctx, user.ID)
+	}
+	return u.tokenRepo.RevokeOtherFamilies(ctx, user.ID, sessionID)
+}
+
+// ListSessions returns the user's active sessions, most recently used first,
+// marking sessionID as the current one.
+func (u *AuthUseCaseImpl) ListSessions(ctx context.Context, userID uint, sessionID string) ([]*domain.Session, error) {
+	tokens, err := u.tokenRepo.ListActiveForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*domain.Session, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, &domain.Session{
+			ID:         token.FamilyID,
+			UserAgent:  token.UserAgent,
+			IP:         token.IP,
+			LastUsedAt: token.LastUsedAt,
+			ExpiresAt:  token.ExpiresAt,
+			Current:    sessionID != "" && token.FamilyID == sessionID,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs out one of the user's active sessions. Revoking the
+// current session logs the caller out once their access token expires.
+func (u *AuthUseCaseImpl) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	tokens, err := u.tokenRepo.ListActiveForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.FamilyID == sessionID {
+			return u.tokenRepo.RevokeFamily(ctx, sessionID)
+		}
+	}
+	return config.ErrSessionNotFound
+}
+
+// issueRefreshToken signs a refresh token with the given jti and stores it in
+// familyID, recording client as the session's last use.
+func (u *AuthUseCaseImpl) issueRefreshToken(ctx context.Context, user *domain.User, familyID, jti string, client domain.ClientInfo) (string, error) {
+	refreshToken, expiresAt, err := u.jwtService.GenerateRefreshToken(user, jti)
 	if err != nil {
 		return "", config.ErrInternalServer
 	}
-	return accessToken, nil
-}
\ No newline at end of file
+	_, err = u.tokenRepo.Create(ctx, &domain.RefreshToken{
+		JTI:        jti,
+		FamilyID:   familyID,
+		UserID:     user.ID,
+		ExpiresAt:  expiresAt,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		LastUsedAt: time.Now(),
+	})
+	if err != nil {
+		return "", config.ErrInternalServer
+	}
+	return refreshToken, nil
+}
+
+// findRefreshToken loads the stored record of a validated refresh token.
+// Tokens without a jti predate persisted refresh tokens and are rejected.
+func (u *AuthUseCaseImpl) findRefreshToken(ctx context.Context, claims *domain.TokenClaims) (*domain.RefreshToken, error) {
+	if claims.JTI == "" {
+		return nil, config.ErrUnauthorized
+	}
+	stored, err := u.tokenRepo.FindByJTI(ctx, claims.JTI)
+	if err == config.ErrNotFound {
+		return nil, config.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return stored, nil
+}
+
+func (u *AuthUseCaseImpl) revokeFamily(ctx context.Context, stored *domain.RefreshToken) error {
+	if err := u.tokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return config.ErrInternalServer
+	}
+	return config.ErrRefreshTokenReused
+}
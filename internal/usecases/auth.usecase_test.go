@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,31 +18,62 @@ type MockJWTService struct {
 	mock.Mock
 }
 
-func (m *MockJWTService) GenerateAccessToken(user *domain.User) (string, error) {
-	args := m.Called(user)
+func (m *MockJWTService) GenerateAccessToken(user *domain.User, sessionID string) (string, error) {
+	args := m.Called(user, sessionID)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockJWTService) GenerateRefreshToken(user *domain.User) (string, error) {
-	args := m.Called(user)
-	return args.String(0), args.Error(1)
+func (m *MockJWTService) GenerateRefreshToken(user *domain.User, jti string) (string, time.Time, error) {
+	args := m.Called(user, jti)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
 }
 
-func (m *MockJWTService) GenerateInviteToken(email string, userID uint) (string, error) {
-	args := m.Called(email, userID)
+func (m *MockJWTService) GenerateInviteToken(email, role string, userID uint) (string, error) {
+	args := m.Called(email, role, userID)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockJWTService) ValidateToken(token string) (map[string]interface{}, error) {
+func (m *MockJWTService) ValidateAccessToken(token string) (*domain.TokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*domain.TokenClaims)
+	return claims, args.Error(1)
+}
+
+func (m *MockJWTService) ValidateRefreshToken(token string) (*domain.TokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*domain.TokenClaims)
+	return claims, args.Error(1)
+}
+
+func (m *MockJWTService) ValidateInviteToken(token string) (*domain.TokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*domain.TokenClaims)
+	return claims, args.Error(1)
+}
+
+func (m *MockJWTService) GeneratePasswordResetToken(user *domain.User, jti string) (string, time.Time, error) {
+	args := m.Called(user, jti)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockJWTService) ValidatePasswordResetToken(token string) (*domain.TokenClaims, error) {
 	args := m.Called(token)
-	return args.Get(0).(map[string]interface{}), args.Error(1)
+	claims, _ := args.Get(0).(*domain.TokenClaims)
+	return claims, args.Error(1)
+}
+
+func (m *MockJWTService) JWKS() *domain.JWKS {
+	args := m.Called()
+	return args.Get(0).(*domain.JWKS)
 }
 
 func TestAuthUseCase_Login(t *testing.T) {
 	ctx := context.Background()
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
 	mockJWTService := &MockJWTService{}
-	uc := NewAuthUseCase(mockUserRepo, mockJWTService)
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mockTokenRepo, mocks.NewPasswordResetRepository(t), mockJWTService, &MockEmailService{})
+	client := domain.ClientInfo{UserAgent: "test-agent", IP: "10.0.0.1"}
 
 	tests := []struct {
 		name            string
@@ -60,8 +92,12 @@ func TestAuthUseCase_Login(t *testing.T) {
 				hashedPassword, _ := services.HashPassword("password123")
 				user := &domain.User{ID: 1, Email: "test@example.com", Password: hashedPassword}
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(user, nil).Once()
-				mockJWTService.On("GenerateAccessToken", user).Return("access_token", nil).Once()
-				mockJWTService.On("GenerateRefreshToken", user).Return("refresh_token", nil).Once()
+				mockJWTService.On("GenerateAccessToken", user, mock.AnythingOfType("string")).Return("access_token", nil).Once()
+				mockJWTService.On("GenerateRefreshToken", user, mock.AnythingOfType("string")).Return("refresh_token", time.Now().Add(time.Hour), nil).Once()
+				mockTokenRepo.On("Create", ctx, mock.MatchedBy(func(token *domain.RefreshToken) bool {
+					return token.UserID == 1 && token.JTI != "" && token.FamilyID != "" &&
+						token.UserAgent == client.UserAgent && token.IP == client.IP && !token.LastUsedAt.IsZero()
+				})).Return(&domain.RefreshToken{}, nil).Once()
 			},
 			expectedAccess:  "access_token",
 			expectedRefresh: "refresh_token",
@@ -91,6 +127,20 @@ func TestAuthUseCase_Login(t *testing.T) {
 			expectedRefresh: "",
 			expectedErr:     config.ErrUnauthorized,
 		},
+		{
+			name:     "Deactivated user",
+			email:    "test@example.com",
+			password: "password123",
+			mockSetup: func() {
+				hashedPassword, _ := services.HashPassword("password123")
+				deactivatedAt := time.Now()
+				user := &domain.User{ID: 1, Email: "test@example.com", Password: hashedPassword, DeactivatedAt: &deactivatedAt}
+				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(user, nil).Once()
+			},
+			expectedAccess:  "",
+			expectedRefresh: "",
+			expectedErr:     config.ErrUserDeactivated,
+		},
 		{
 			name:     "Access token generation failure",
 			email:    "test@example.com",
@@ -99,7 +149,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				hashedPassword, _ := services.HashPassword("password123")
 				user := &domain.User{ID: 1, Email: "test@example.com", Password: hashedPassword}
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(user, nil).Once()
-				mockJWTService.On("GenerateAccessToken", user).Return("", errors.New("token error")).Once()
+				mockJWTService.On("GenerateAccessToken", user, mock.AnythingOfType("string")).Return("", errors.New("token error")).Once()
 			},
 			expectedAccess:  "",
 			expectedRefresh: "",
@@ -113,8 +163,8 @@ func TestAuthUseCase_Login(t *testing.T) {
 				hashedPassword, _ := services.HashPassword("password123")
 				user := &domain.User{ID: 1, Email: "test@example.com", Password: hashedPassword}
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(user, nil).Once()
-				mockJWTService.On("GenerateAccessToken", user).Return("access_token", nil).Once()
-				mockJWTService.On("GenerateRefreshToken", user).Return("", errors.New("token error")).Once()
+				mockJWTService.On("GenerateAccessToken", user, mock.AnythingOfType("string")).Return("access_token", nil).Once()
+				mockJWTService.On("GenerateRefreshToken", user, mock.AnythingOfType("string")).Return("", time.Time{}, errors.New("token error")).Once()
 			},
 			expectedAccess:  "",
 			expectedRefresh: "",
@@ -125,7 +175,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			accessToken, refreshToken, err := uc.Login(ctx, tt.email, tt.password)
+			accessToken, refreshToken, err := uc.Login(ctx, tt.email, tt.password, client)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -144,8 +194,15 @@ func TestAuthUseCase_Login(t *testing.T) {
 func TestAuthUseCase_RegisterFromInvite(t *testing.T) {
 	ctx := context.Background()
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockInviteRepo := mocks.NewInviteRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
 	mockJWTService := &MockJWTService{}
-	uc := NewAuthUseCase(mockUserRepo, mockJWTService)
+	uc := NewAuthUseCase(mockUserRepo, mockInviteRepo, mockTokenRepo, mocks.NewPasswordResetRepository(t), mockJWTService, &MockEmailService{})
+
+	pending := func() *domain.Invite {
+		return &domain.Invite{ID: 1, Token: "valid_token", Email: "test@example.com", Role: domain.RoleAnalyst, Expiry: time.Now().Add(time.Hour)}
+	}
+	claims := &domain.TokenClaims{Email: "test@example.com", Role: domain.RoleAnalyst, Type: domain.TokenTypeInvite}
 
 	tests := []struct {
 		name        string
@@ -159,44 +216,77 @@ func TestAuthUseCase_RegisterFromInvite(t *testing.T) {
 			token:    "valid_token",
 			password: "password123",
 			mockSetup: func() {
-				claims := map[string]interface{}{"email": "test@example.com"}
-				mockJWTService.On("ValidateToken", "valid_token").Return(claims, nil).Once()
-				hashedPassword, _ := services.HashPassword("password123")
-				user := &domain.User{Email: "test@example.com", Password: hashedPassword, Role: "uploader"}
-				mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Return(user, nil).Once()
+				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(pending(), nil).Once()
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(claims, nil).Once()
+				mockInviteRepo.On("Accept", ctx, uint(1), mock.MatchedBy(func(user *domain.User) bool {
+					return user.Email == "test@example.com" && user.Role == domain.RoleAnalyst
+				})).Return(true, nil).Once()
 			},
 			expectedErr: nil,
 		},
+		{
+			name:     "Unknown invite token",
+			token:    "unknown_token",
+			password: "password123",
+			mockSetup: func() {
+				mockInviteRepo.On("FindByToken", ctx, "unknown_token").Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrInviteNotFound,
+		},
+		{
+			name:     "Invite already used",
+			token:    "used_token",
+			password: "password123",
+			mockSetup: func() {
+				invite := pending()
+				invite.Used = true
+				mockInviteRepo.On("FindByToken", ctx, "used_token").Return(invite, nil).Once()
+			},
+			expectedErr: config.ErrInviteAccepted,
+		},
 		{
 			name:     "Invalid invite token",
-			token:    "invalid_token",
+			token:    "valid_token",
 			password: "password123",
 			mockSetup: func() {
-				mockJWTService.On("ValidateToken", "invalid_token").Return(map[string]interface{}{}, errors.New("invalid token")).Once()
+				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(pending(), nil).Once()
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(nil, errors.New("invalid token")).Once()
 			},
 			expectedErr: config.ErrUnauthorized,
 		},
 		{
-			name:     "Invalid email in token claims",
+			name:     "Token role does not match the invite",
 			token:    "valid_token",
 			password: "password123",
 			mockSetup: func() {
-				claims := map[string]interface{}{"email": 123} 
-				mockJWTService.On("ValidateToken", "valid_token").Return(claims, nil).Once()
+				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(pending(), nil).Once()
+				adminClaims := &domain.TokenClaims{Email: "test@example.com", Role: domain.RoleAdmin, Type: domain.TokenTypeInvite}
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(adminClaims, nil).Once()
 			},
-			expectedErr: config.ErrBadRequest,
+			expectedErr: config.ErrUnauthorized,
 		},
 		{
 			name:     "User creation failure",
 			token:    "valid_token",
 			password: "password123",
 			mockSetup: func() {
-				claims := map[string]interface{}{"email": "test@example.com"}
-				mockJWTService.On("ValidateToken", "valid_token").Return(claims, nil).Once()
-				mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Return(nil, errors.New("creation error")).Once()
+				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(pending(), nil).Once()
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(claims, nil).Once()
+				mockInviteRepo.On("Accept", ctx, uint(1), mock.AnythingOfType("*domain.User")).Return(false, config.ErrInternalServer).Once()
 			},
 			expectedErr: config.ErrInternalServer,
 		},
+		{
+			name:     "Invite accepted by a concurrent registration",
+			token:    "valid_token",
+			password: "password123",
+			mockSetup: func() {
+				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(pending(), nil).Once()
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(claims, nil).Once()
+				mockInviteRepo.On("Accept", ctx, uint(1), mock.AnythingOfType("*domain.User")).Return(false, nil).Once()
+			},
+			expectedErr: config.ErrInviteAccepted,
+		},
 	}
 
 	for _, tt := range tests {
@@ -217,74 +307,461 @@ func TestAuthUseCase_RegisterFromInvite(t *testing.T) {
 func TestAuthUseCase_RefreshToken(t *testing.T) {
 	ctx := context.Background()
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
 	mockJWTService := &MockJWTService{}
-	uc := NewAuthUseCase(mockUserRepo, mockJWTService)
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mockTokenRepo, mocks.NewPasswordResetRepository(t), mockJWTService, &MockEmailService{})
+	client := domain.ClientInfo{UserAgent: "test-agent", IP: "10.0.0.1"}
+
+	claims := &domain.TokenClaims{UserID: 1, JTI: "jti-1", Type: domain.TokenTypeRefresh}
+	user := &domain.User{ID: 1, Email: "test@example.com", Role: "admin"}
+	deactivatedAt := time.Now()
+	deactivated := &domain.User{ID: 1, Email: "test@example.com", Role: "admin", DeactivatedAt: &deactivatedAt}
+	active := &domain.RefreshToken{JTI: "jti-1", FamilyID: "family-1", UserID: 1}
+	revokedAt := time.Now()
+	revoked := &domain.RefreshToken{JTI: "jti-1", FamilyID: "family-1", UserID: 1, RevokedAt: &revokedAt}
 
 	tests := []struct {
-		name           string
-		refreshToken   string
-		mockSetup      func()
-		expectedAccess string
-		expectedErr    error
+		name            string
+		refreshToken    string
+		mockSetup       func()
+		expectedAccess  string
+		expectedRefresh string
+		expectedErr     error
 	}{
 		{
-			name:         "Valid refresh token",
+			name:         "Valid refresh token is rotated",
 			refreshToken: "valid_refresh_token",
 			mockSetup: func() {
-				claims := map[string]interface{}{"id": 1.0}
-				mockJWTService.On("ValidateToken", "valid_refresh_token").Return(claims, nil).Once()
-				user := &domain.User{ID: 1}
-				mockJWTService.On("GenerateAccessToken", user).Return("new_access_token", nil).Once()
+				mockJWTService.On("ValidateRefreshToken", "valid_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(active, nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(user, nil).Once()
+				mockJWTService.On("GenerateAccessToken", user, "family-1").Return("new_access_token", nil).Once()
+				mockTokenRepo.On("Revoke", ctx, "jti-1", mock.AnythingOfType("string")).Return(true, nil).Once()
+				mockJWTService.On("GenerateRefreshToken", user, mock.AnythingOfType("string")).Return("new_refresh_token", time.Now().Add(time.Hour), nil).Once()
+				mockTokenRepo.On("Create", ctx, mock.MatchedBy(func(token *domain.RefreshToken) bool {
+					return token.FamilyID == "family-1" && token.JTI != "jti-1" && token.UserAgent == client.UserAgent
+				})).Return(&domain.RefreshToken{}, nil).Once()
 			},
-			expectedAccess: "new_access_token",
-			expectedErr:    nil,
+			expectedAccess:  "new_access_token",
+			expectedRefresh: "new_refresh_token",
+			expectedErr:     nil,
 		},
 		{
 			name:         "Invalid refresh token",
 			refreshToken: "invalid_refresh_token",
 			mockSetup: func() {
-				mockJWTService.On("ValidateToken", "invalid_refresh_token").Return(map[string]interface{}{}, errors.New("invalid token")).Once()
+				mockJWTService.On("ValidateRefreshToken", "invalid_refresh_token").Return(nil, errors.New("invalid token")).Once()
 			},
-			expectedAccess: "",
-			expectedErr:    config.ErrUnauthorized,
+			expectedErr: config.ErrUnauthorized,
 		},
 		{
-			name:         "Invalid user ID in token claims",
-			refreshToken: "valid_refresh_token",
+			name:         "Token without jti",
+			refreshToken: "stateless_refresh_token",
 			mockSetup: func() {
-				claims := map[string]interface{}{"id": "invalid"} 
-				mockJWTService.On("ValidateToken", "valid_refresh_token").Return(claims, nil).Once()
+				claims := &domain.TokenClaims{UserID: 1, Type: domain.TokenTypeRefresh}
+				mockJWTService.On("ValidateRefreshToken", "stateless_refresh_token").Return(claims, nil).Once()
 			},
-			expectedAccess: "",
-			expectedErr:    config.ErrBadRequest,
+			expectedErr: config.ErrUnauthorized,
+		},
+		{
+			name:         "Deactivated user loses the session",
+			refreshToken: "deactivated_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "deactivated_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(active, nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(deactivated, nil).Once()
+				mockTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil).Once()
+			},
+			expectedErr: config.ErrUserDeactivated,
+		},
+		{
+			name:         "Deleted user",
+			refreshToken: "deleted_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "deleted_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(active, nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrUnauthorized,
+		},
+		{
+			name:         "Unknown jti",
+			refreshToken: "unknown_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "unknown_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrUnauthorized,
+		},
+		{
+			name:         "Reused token revokes the family",
+			refreshToken: "reused_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "reused_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(revoked, nil).Once()
+				mockTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil).Once()
+			},
+			expectedErr: config.ErrRefreshTokenReused,
+		},
+		{
+			name:         "Concurrent rotation revokes the family",
+			refreshToken: "raced_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "raced_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(active, nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(user, nil).Once()
+				mockJWTService.On("GenerateAccessToken", user, "family-1").Return("new_access_token", nil).Once()
+				mockTokenRepo.On("Revoke", ctx, "jti-1", mock.AnythingOfType("string")).Return(false, nil).Once()
+				mockTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil).Once()
+			},
+			expectedErr: config.ErrRefreshTokenReused,
 		},
 		{
 			name:         "Access token generation failure",
-			refreshToken: "valid_refresh_token",
+			refreshToken: "failing_refresh_token",
 			mockSetup: func() {
-				claims := map[string]interface{}{"id": 1.0}
-				mockJWTService.On("ValidateToken", "valid_refresh_token").Return(claims, nil).Once()
-				user := &domain.User{ID: 1}
-				mockJWTService.On("GenerateAccessToken", user).Return("", errors.New("token error")).Once()
+				mockJWTService.On("ValidateRefreshToken", "failing_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(active, nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(user, nil).Once()
+				mockJWTService.On("GenerateAccessToken", user, "family-1").Return("", errors.New("token error")).Once()
 			},
-			expectedAccess: "",
-			expectedErr:    config.ErrInternalServer,
+			expectedErr: config.ErrInternalServer,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			accessToken, err := uc.RefreshToken(ctx, tt.refreshToken)
+			accessToken, refreshToken, err := uc.RefreshToken(ctx, tt.refreshToken, client)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.expectedErr.Error())
-				assert.Equal(t, tt.expectedAccess, accessToken)
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Empty(t, accessToken)
+				assert.Empty(t, refreshToken)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedAccess, accessToken)
+				assert.Equal(t, tt.expectedRefresh, refreshToken)
 			}
 		})
 	}
 }
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	mockJWTService := &MockJWTService{}
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mockTokenRepo, mocks.NewPasswordResetRepository(t), mockJWTService, &MockEmailService{})
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		mockSetup    func()
+		expectedErr  error
+	}{
+		{
+			name:         "Revokes the session",
+			refreshToken: "valid_refresh_token",
+			mockSetup: func() {
+				claims := &domain.TokenClaims{UserID: 1, JTI: "jti-1", Type: domain.TokenTypeRefresh}
+				mockJWTService.On("ValidateRefreshToken", "valid_refresh_token").Return(claims, nil).Once()
+				mockTokenRepo.On("FindByJTI", ctx, "jti-1").Return(&domain.RefreshToken{JTI: "jti-1", FamilyID: "family-1"}, nil).Once()
+				mockTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "Invalid refresh token",
+			refreshToken: "invalid_refresh_token",
+			mockSetup: func() {
+				mockJWTService.On("ValidateRefreshToken", "invalid_refresh_token").Return(nil, errors.New("invalid token")).Once()
+			},
+			expectedErr: config.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := uc.Logout(ctx, tt.refreshToken)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUseCase_ForgotPassword(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockResetRepo := mocks.NewPasswordResetRepository(t)
+	mockJWTService := &MockJWTService{}
+	mockEmailService := &MockEmailService{}
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mocks.NewRefreshTokenRepository(t), mockResetRepo, mockJWTService, mockEmailService)
+
+	deactivatedAt := time.Now()
+	tests := []struct {
+		name        string
+		email       string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name:  "Reset link emailed",
+			email: "user@example.com",
+			mockSetup: func() {
+				user := &domain.User{ID: 1, Email: "user@example.com"}
+				expiresAt := time.Now().Add(30 * time.Minute)
+				mockUserRepo.On("FindByEmail", ctx, "user@example.com").Return(user, nil).Once()
+				mockJWTService.On("GeneratePasswordResetToken", user, mock.AnythingOfType("string")).Return("reset_token", expiresAt, nil).Once()
+				mockResetRepo.On("Create", ctx, mock.MatchedBy(func(reset *domain.PasswordReset) bool {
+					return reset.UserID == 1 && reset.JTI != "" && reset.ExpiresAt.Equal(expiresAt)
+				})).Return(&domain.PasswordReset{}, nil).Once()
+				mockEmailService.On("SendPasswordReset", "user@example.com", "http://localhost:8080/reset-password?token=reset_token").Return(nil).Once()
+			},
+		},
+		{
+			name:  "Failure to issue the link is not reported",
+			email: "user@example.com",
+			mockSetup: func() {
+				user := &domain.User{ID: 1, Email: "user@example.com"}
+				mockUserRepo.On("FindByEmail", ctx, "user@example.com").Return(user, nil).Once()
+				mockJWTService.On("GeneratePasswordResetToken", user, mock.AnythingOfType("string")).Return("", time.Time{}, errors.New("sign error")).Once()
+			},
+		},
+		{
+			name:  "Unknown email gets the same answer",
+			email: "nobody@example.com",
+			mockSetup: func() {
+				mockUserRepo.On("FindByEmail", ctx, "nobody@example.com").Return(nil, config.ErrNotFound).Once()
+			},
+		},
+		{
+			name:  "Deactivated user gets no email",
+			email: "gone@example.com",
+			mockSetup: func() {
+				mockUserRepo.On("FindByEmail", ctx, "gone@example.com").Return(&domain.User{ID: 2, DeactivatedAt: &deactivatedAt}, nil).Once()
+			},
+		},
+		{
+			name:  "Database error",
+			email: "user@example.com",
+			mockSetup: func() {
+				mockUserRepo.On("FindByEmail", ctx, "user@example.com").Return(nil, config.ErrInternalServer).Once()
+			},
+			expectedErr: config.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := uc.ForgotPassword(ctx, tt.email)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+	mockJWTService.AssertExpectations(t)
+	mockEmailService.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	mockResetRepo := mocks.NewPasswordResetRepository(t)
+	mockJWTService := &MockJWTService{}
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mockTokenRepo, mockResetRepo, mockJWTService, &MockEmailService{})
+
+	claims := &domain.TokenClaims{UserID: 1, JTI: "reset-1", Type: domain.TokenTypePasswordReset}
+	pending := func() *domain.PasswordReset {
+		return &domain.PasswordReset{JTI: "reset-1", UserID: 1, ExpiresAt: time.Now().Add(10 * time.Minute)}
+	}
+	usedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "Password reset and sessions revoked",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(pending(), nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old_hash"}, nil).Once()
+				mockResetRepo.On("Redeem", ctx, "reset-1", uint(1), mock.MatchedBy(func(hashedPassword string) bool {
+					return services.CheckPasswordHash("NewPass1!", hashedPassword)
+				})).Return(true, nil).Once()
+				mockTokenRepo.On("RevokeAllForUser", ctx, uint(1)).Return(nil).Once()
+			},
+		},
+		{
+			name: "Failed redeem leaves nothing to revoke",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(pending(), nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old_hash"}, nil).Once()
+				mockResetRepo.On("Redeem", ctx, "reset-1", uint(1), mock.AnythingOfType("string")).Return(false, config.ErrInternalServer).Once()
+			},
+			expectedErr: config.ErrInternalServer,
+		},
+		{
+			name: "Failure to revoke sessions is reported as an internal error",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(pending(), nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old_hash"}, nil).Once()
+				mockResetRepo.On("Redeem", ctx, "reset-1", uint(1), mock.AnythingOfType("string")).Return(true, nil).Once()
+				mockTokenRepo.On("RevokeAllForUser", ctx, uint(1)).Return(errors.New("connection reset")).Once()
+			},
+			expectedErr: config.ErrInternalServer,
+		},
+		{
+			name: "Invalid token",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(nil, errors.New("token is expired")).Once()
+			},
+			expectedErr: config.ErrResetTokenInvalid,
+		},
+		{
+			name: "Link already used",
+			mockSetup: func() {
+				reset := pending()
+				reset.UsedAt = &usedAt
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(reset, nil).Once()
+			},
+			expectedErr: config.ErrResetTokenInvalid,
+		},
+		{
+			name: "Link used concurrently",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(pending(), nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Once()
+				mockResetRepo.On("Redeem", ctx, "reset-1", uint(1), mock.AnythingOfType("string")).Return(false, nil).Once()
+			},
+			expectedErr: config.ErrResetTokenInvalid,
+		},
+		{
+			name: "Unknown link",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrResetTokenInvalid,
+		},
+		{
+			name: "Deactivated user",
+			mockSetup: func() {
+				mockJWTService.On("ValidatePasswordResetToken", "reset_token").Return(claims, nil).Once()
+				mockResetRepo.On("FindByJTI", ctx, "reset-1").Return(pending(), nil).Once()
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, DeactivatedAt: &usedAt}, nil).Once()
+			},
+			expectedErr: config.ErrUserDeactivated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := uc.ResetPassword(ctx, "reset_token", "NewPass1!")
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	mockResetRepo := mocks.NewPasswordResetRepository(t)
+	uc := NewAuthUseCase(mockUserRepo, mocks.NewInviteRepository(t), mockTokenRepo, mockResetRepo, &MockJWTService{}, &MockEmailService{})
+
+	hashedPassword, _ := services.HashPassword("OldPass1!")
+	deactivatedAt := time.Now()
+
+	tests := []struct {
+		name            string
+		sessionID       string
+		currentPassword string
+		mockSetup       func()
+		expectedErr     error
+	}{
+		{
+			name:            "Password changed and other sessions revoked",
+			sessionID:       "family-1",
+			currentPassword: "OldPass1!",
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: hashedPassword}, nil).Once()
+				mockUserRepo.On("Update", ctx, mock.MatchedBy(func(user *domain.User) bool {
+					return services.CheckPasswordHash("NewPass1!", user.Password)
+				})).Return(&domain.User{}, nil).Once()
+				mockResetRepo.On("InvalidateAllForUser", ctx, uint(1)).Return(nil).Once()
+				mockTokenRepo.On("RevokeOtherFamilies", ctx, uint(1), "family-1").Return(nil).Once()
+			},
+		},
+		{
+			name:            "Token without session revokes every session",
+			currentPassword: "OldPass1!",
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: hashedPassword}, nil).Once()
+				mockUserRepo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(&domain.User{}, nil).Once()
+				mockResetRepo.On("InvalidateAllForUser", ctx, uint(1)).Return(nil).Once()
+				mockTokenRepo.On("RevokeAllForUser", ctx, uint(1)).Return(nil).Once()
+			},
+		},
+		{
+			name:            "Wrong current password",
+			sessionID:       "family-1",
+			currentPassword: "WrongPass1!",
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: hashedPassword}, nil).Once()
+			},
+			expectedErr: config.ErrIncorrectPassword,
+		},
+		{
+			name:            "Deactivated user",
+			sessionID:       "family-1",
+			currentPassword: "OldPass1!",
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(1)).Return(&domain.User{ID: 1, Password: hashedPassword, DeactivatedAt: &deactivatedAt}, nil).Once()
+			},
+			expectedErr: config.ErrUserDeactivated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			err := uc.ChangePassword(ctx, 1, tt.sessionID, tt.currentPassword, "NewPass1!")
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthUseCase_Sessions(t *testing.T) {
+	ctx := context.Background()
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	uc := NewAuthUseCase(mocks.NewUserRepository(t), mocks.NewInviteRepository(t), mockTokenRepo, mocks.NewPasswordResetRepository(t), &MockJWTService{}, &MockEmailService{})
+
+	lastUsed := time.Now()
+	active := []*domain.RefreshToken{
+		{FamilyID: "family-2", UserID: 1, UserAgent: "phone", IP: "10.0.0.2", LastUsedAt: lastUsed},
+		{FamilyID: "family-1", UserID: 1, UserAgent: "laptop", IP: "10.0.0.1", LastUsedAt: lastUsed.Add(-time.Hour)},
+	}
+	mockTokenRepo.On("ListActiveForUser", ctx, uint(1)).Return(active, nil)
+
+	sessions, err := uc.ListSessions(ctx, 1, "family-1")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "family-2", sessions[0].ID)
+	assert.Equal(t, "phone", sessions[0].UserAgent)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+
+	mockTokenRepo.On("RevokeFamily", ctx, "family-2").Return(nil).Once()
+	assert.NoError(t, uc.RevokeSession(ctx, 1, "family-2"))
+
+	assert.Equal(t, config.ErrSessionNotFound, uc.RevokeSession(ctx, 1, "family-of-another-user"))
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+type BacktestUseCaseImpl struct {
+	customerRepo  domain.CustomerRepository
+	scorecardRepo domain.ScorecardRepository
+	outcomeRepo   domain.RepaymentOutcomeRepository
+}
+
+func NewBacktestUseCase(customerRepo domain.CustomerRepository, scorecardRepo domain.ScorecardRepository, outcomeRepo domain.RepaymentOutcomeRepository) *BacktestUseCaseImpl {
+	return &BacktestUseCaseImpl{
+		customerRepo:  customerRepo,
+		scorecardRepo: scorecardRepo,
+		outcomeRepo:   outcomeRepo,
+	}
+}
+
+// LoadOutcomes stores a JSON array of repayment outcomes for later backtests
+// and returns how many were stored.
+func (u *BacktestUseCaseImpl) LoadOutcomes(ctx context.Context, file io.Reader) (int, error) {
+	outcomes, err := parseOutcomes(file)
+	if err != nil {
+		return 0, err
+	}
+	if len(outcomes) == 0 {
+		return 0, config.ErrNoOutcomes
+	}
+	if err := u.outcomeRepo.SaveOutcomes(ctx, outcomes); err != nil {
+		return 0, err
+	}
+	return len(outcomes), nil
+}
+
+// Run rates every advance in the outcome set as of its decision date with each
+// of the given scorecard versions and reports how well the ratings separate
+// defaults. When file is nil the stored outcomes are used. With a productName,
+// only advances to customers of that product are rated, since those are the
+// customers the product's scorecards rate.
+func (u *BacktestUseCaseImpl) Run(ctx context.Context, productName string, versions []string, file io.Reader) (*domain.BacktestComparison, error) {
+	if len(versions) == 0 || len(versions) > 2 {
+		return nil, config.ErrBadRequest
+	}
+	scorecards := make([]*domain.Scorecard, 0, len(versions))
+	for _, version := range versions {
+		scorecard, err := u.findScorecard(ctx, productName, version)
+		if err != nil {
+			return nil, err
+		}
+		scorecards = append(scorecards, scorecard)
+	}
+
+	var outcomes []*domain.RepaymentOutcome
+	var err error
+	if file != nil {
+		outcomes, err = parseOutcomes(file)
+	} else {
+		outcomes, err = u.outcomeRepo.ListOutcomes(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(outcomes) == 0 {
+		return nil, config.ErrNoOutcomes
+	}
+
+	type ratingInput struct {
+		customer     *domain.Customer
+		transactions []*domain.Transaction
+		err          error
+	}
+	inputs := make(map[string]*ratingInput)
+	comparison := &domain.BacktestComparison{
+		ProductName: productName,
+		Skipped:     []domain.RatingFailure{},
+	}
+	population := make([]*domain.RepaymentOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		input, ok := inputs[outcome.CustomerID]
+		if !ok {
+			input = &ratingInput{}
+			input.customer, input.err = u.customerRepo.FindByID(ctx, outcome.CustomerID)
+			if input.err == nil {
+				input.transactions, input.err = u.customerRepo.GetTransactionsByAccount(ctx, string(input.customer.AccountNo))
+			}
+			inputs[outcome.CustomerID] = input
+		}
+		if input.err != nil {
+			comparison.Skipped = append(comparison.Skipped, domain.RatingFailure{
+				CustomerID: outcome.CustomerID,
+				Error:      fmt.Sprintf("loan %s: %v", outcome.LoanID, input.err),
+			})
+			continue
+		}
+		if productName != "" && input.customer.ProductName != productName {
+			continue
+		}
+		population = append(population, outcome)
+	}
+	comparison.Population = len(population)
+
+	strategy := NewScorecardStrategy()
+	for _, scorecard := range scorecards {
+		ratings := make([]float64, len(population))
+		for i, outcome := range population {
+			input := inputs[outcome.CustomerID]
+			asOf := outcome.DecisionDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			ratings[i] = strategy.Rate(newRatingInput(outcome.CustomerID, input.customer, input.transactions, scorecard, asOf)).Rating
+		}
+		comparison.Reports = append(comparison.Reports, backtestReport(scorecard.Version, population, ratings))
+	}
+	return comparison, nil
+}
+
+// findScorecard looks up a stored version; the built-in scorecards can be
+// backtested by their version names even though they are never stored.
+func (u *BacktestUseCaseImpl) findScorecard(ctx context.Context, productName, version string) (*domain.Scorecard, error) {
+	scorecard, err := u.scorecardRepo.FindByVersion(ctx, productName, version)
+	if err == config.ErrScorecardNotFound {
+		if builtin := domain.BuiltinScorecard(version); builtin != nil {
+			return builtin, nil
+		}
+	}
+	return scorecard, err
+}
+
+func parseOutcomes(file io.Reader) ([]*domain.RepaymentOutcome, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	var input []*domain.RepaymentOutcomeInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON format: %v", config.ErrInvalidOutcome, err)
+	}
+
+	outcomes := make([]*domain.RepaymentOutcome, 0, len(input))
+	seen := make(map[string]bool, len(input))
+	for i, in := range input {
+		if in == nil {
+			return nil, fmt.Errorf("%w: record %d is empty", config.ErrInvalidOutcome, i+1)
+		}
+		loanID := strings.TrimSpace(in.LoanID)
+		customerID := strings.TrimSpace(in.CustomerID)
+		if loanID == "" || customerID == "" {
+			return nil, fmt.Errorf("%w: record %d needs loanId and customerId", config.ErrInvalidOutcome, i+1)
+		}
+		if seen[loanID] {
+			return nil, fmt.Errorf("%w: record %d repeats loanId %s", config.ErrInvalidOutcome, i+1, loanID)
+		}
+		seen[loanID] = true
+		date, err := time.Parse("2006-01-02", in.DecisionDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d has an invalid decisionDate, expected YYYY-MM-DD", config.ErrInvalidOutcome, i+1)
+		}
+		outcome := strings.ToLower(strings.TrimSpace(in.Outcome))
+		if outcome != domain.OutcomeRepaid && outcome != domain.OutcomeLate && outcome != domain.OutcomeDefaulted {
+			return nil, fmt.Errorf("%w: record %d has unknown outcome %q", config.ErrInvalidOutcome, i+1, in.Outcome)
+		}
+		outcomes = append(outcomes, &domain.RepaymentOutcome{
+			LoanID:       loanID,
+			CustomerID:   customerID,
+			DecisionDate: date,
+			Outcome:      outcome,
+		})
+	}
+	return outcomes, nil
+}
+
+// backtestReport buckets the ratings by one-point band and computes the Gini
+// coefficient and KS statistic of the ratings against defaults.
+func backtestReport(version string, outcomes []*domain.RepaymentOutcome, ratings []float64) domain.BacktestReport {
+	report := domain.BacktestReport{
+		ScorecardVersion: version,
+		Population:       len(outcomes),
+		Buckets:          []domain.BacktestBucket{},
+	}
+	buckets := make(map[string]*domain.BacktestBucket)
+	for i, outcome := range outcomes {
+		band := ratingBand(ratings[i])
+		bucket, ok := buckets[band]
+		if !ok {
+			bucket = &domain.BacktestBucket{Band: band}
+			buckets[band] = bucket
+		}
+		bucket.Count++
+		switch outcome.Outcome {
+		case domain.OutcomeLate:
+			bucket.Late++
+			report.Late++
+		case domain.OutcomeDefaulted:
+			bucket.Defaulted++
+			report.Defaulted++
+		}
+	}
+	for _, bucket := range buckets {
+		bucket.LateRate = roundTo(float64(bucket.Late)/float64(bucket.Count), 4)
+		bucket.DefaultRate = roundTo(float64(bucket.Defaulted)/float64(bucket.Count), 4)
+		report.Buckets = append(report.Buckets, *bucket)
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool {
+		return report.Buckets[i].Band < report.Buckets[j].Band
+	})
+	if report.Population > 0 {
+		report.DefaultRate = roundTo(float64(report.Defaulted)/float64(report.Population), 4)
+	}
+	report.Gini, report.KS = discrimination(outcomes, ratings)
+	return report
+}
+
+// discrimination returns the Gini coefficient (2*AUC - 1, where AUC is the
+// chance a non-defaulted advance is rated above a defaulted one) and the KS
+// statistic, the largest gap between the cumulative rating distributions of
+// defaulted and non-defaulted advances.
+func discrimination(outcomes []*domain.RepaymentOutcome, ratings []float64) (float64, float64) {
+	type point struct {
+		rating float64
+		bad    bool
+	}
+	points := make([]point, len(outcomes))
+	var bads, goods float64
+	for i, outcome := range outcomes {
+		points[i] = point{rating: ratings[i], bad: outcome.Outcome == domain.OutcomeDefaulted}
+		if points[i].bad {
+			bads++
+		} else {
+			goods++
+		}
+	}
+	if bads == 0 || goods == 0 {
+		return 0, 0
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].rating < points[j].rating })
+
+	var pairs, badsBelow, goodsBelow, ks float64
+	for i := 0; i < len(points); {
+		var groupBads, groupGoods float64
+		j := i
+		for ; j < len(points) && points[j].rating == points[i].rating; j++ {
+			if points[j].bad {
+				groupBads++
+			} else {
+				groupGoods++
+			}
+		}
+		pairs += groupGoods * (badsBelow + groupBads/2)
+		badsBelow += groupBads
+		goodsBelow += groupGoods
+		if gap := badsBelow/bads - goodsBelow/goods; gap > ks {
+			ks = gap
+		}
+		i = j
+	}
+	auc := pairs / (bads * goods)
+	return roundTo(2*auc-1, 4), roundTo(ks, 4)
+}
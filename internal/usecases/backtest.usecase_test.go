@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/pkg/config"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBacktestUseCase_Run(t *testing.T) {
+	ctx := context.Background()
+	customerRepo := mocks.NewCustomerRepository(t)
+	scorecardRepo := mocks.NewScorecardRepository(t)
+	uc := NewBacktestUseCase(customerRepo, scorecardRepo, mocks.NewRepaymentOutcomeRepository(t))
+
+	decision := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	input := `[
+		{"loanId": "L1", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "repaid"},
+		{"loanId": "L2", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "late"},
+		{"loanId": "L3", "customerId": "2", "decisionDate": "2025-01-31", "outcome": "defaulted"},
+		{"loanId": "L4", "customerId": "3", "decisionDate": "2025-01-31", "outcome": "defaulted"}
+	]`
+
+	scorecardRepo.On("FindByVersion", ctx, "", "default-v1").Return(nil, config.ErrScorecardNotFound).Once()
+	scorecardRepo.On("FindByVersion", ctx, "", "count-v2").Return(&domain.Scorecard{
+		Version:   "count-v2",
+		Factors:   domain.ScorecardFactors{{Name: domain.FactorCount, Weight: 1, Cap: 2}},
+		Scale:     10,
+		MinRating: 1,
+		MaxRating: 10,
+	}, nil).Once()
+	customerRepo.On("FindByID", ctx, "1").Return(&domain.Customer{ID: 1, AccountNo: "11111", CustomerBalance: 1000}, nil).Once()
+	customerRepo.On("GetTransactionsByAccount", ctx, "11111").Return([]*domain.Transaction{
+		{TransactionID: "TXN-1", FromAccount: "11111", ToAccount: "67890", Amount: 500, Date: decision.AddDate(0, 0, -200)},
+		{TransactionID: "TXN-2", FromAccount: "67890", ToAccount: "11111", Amount: 800, Date: decision.AddDate(0, 0, -20)},
+		{TransactionID: "TXN-3", FromAccount: "11111", ToAccount: "67890", Amount: 300, Date: decision.AddDate(0, 0, 5)},
+	}, nil).Once()
+	customerRepo.On("FindByID", ctx, "2").Return(&domain.Customer{ID: 2, AccountNo: "22222"}, nil).Once()
+	customerRepo.On("GetTransactionsByAccount", ctx, "22222").Return([]*domain.Transaction{}, nil).Once()
+	customerRepo.On("FindByID", ctx, "3").Return(nil, config.ErrNotFound).Once()
+
+	comparison, err := uc.Run(ctx, "", []string{"default-v1", "count-v2"}, bytes.NewReader([]byte(input)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, comparison.Population)
+	assert.Len(t, comparison.Skipped, 1)
+	assert.Equal(t, "3", comparison.Skipped[0].CustomerID)
+	assert.Len(t, comparison.Reports, 2)
+
+	countReport := comparison.Reports[1]
+	assert.Equal(t, "count-v2", countReport.ScorecardVersion)
+	assert.Equal(t, 1, countReport.Late)
+	assert.Equal(t, 1, countReport.Defaulted)
+	assert.Equal(t, []domain.BacktestBucket{
+		{Band: "1-2", Count: 1, Defaulted: 1, DefaultRate: 1},
+		{Band: "9-10", Count: 2, Late: 1, LateRate: 0.5},
+	}, countReport.Buckets)
+	assert.Equal(t, 1.0, countReport.Gini)
+	assert.Equal(t, 1.0, countReport.KS)
+	assert.Equal(t, "default-v1", comparison.Reports[0].ScorecardVersion)
+}
+
+func TestBacktestUseCase_RunFiltersByProduct(t *testing.T) {
+	ctx := context.Background()
+	customerRepo := mocks.NewCustomerRepository(t)
+	scorecardRepo := mocks.NewScorecardRepository(t)
+	uc := NewBacktestUseCase(customerRepo, scorecardRepo, mocks.NewRepaymentOutcomeRepository(t))
+
+	input := `[
+		{"loanId": "L1", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "repaid"},
+		{"loanId": "L2", "customerId": "2", "decisionDate": "2025-01-31", "outcome": "defaulted"}
+	]`
+	scorecardRepo.On("FindByVersion", ctx, "Payroll", "payroll-v1").Return(&domain.Scorecard{
+		Version:     "payroll-v1",
+		ProductName: "Payroll",
+		Factors:     domain.ScorecardFactors{{Name: domain.FactorCount, Weight: 1, Cap: 2}},
+		Scale:       10,
+		MinRating:   1,
+		MaxRating:   10,
+	}, nil).Once()
+	customerRepo.On("FindByID", ctx, "1").Return(&domain.Customer{ID: 1, AccountNo: "11111", ProductName: "Payroll"}, nil).Once()
+	customerRepo.On("GetTransactionsByAccount", ctx, "11111").Return([]*domain.Transaction{}, nil).Once()
+	customerRepo.On("FindByID", ctx, "2").Return(&domain.Customer{ID: 2, AccountNo: "22222", ProductName: "Personal"}, nil).Once()
+	customerRepo.On("GetTransactionsByAccount", ctx, "22222").Return([]*domain.Transaction{}, nil).Once()
+
+	comparison, err := uc.Run(ctx, "Payroll", []string{"payroll-v1"}, bytes.NewReader([]byte(input)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, comparison.Population)
+	assert.Empty(t, comparison.Skipped)
+	assert.Equal(t, 0, comparison.Reports[0].Defaulted)
+}
+
+func TestBacktestUseCase_RunRequiresVersions(t *testing.T) {
+	uc := NewBacktestUseCase(mocks.NewCustomerRepository(t), mocks.NewScorecardRepository(t), mocks.NewRepaymentOutcomeRepository(t))
+
+	_, err := uc.Run(context.Background(), "", nil, nil)
+	assert.Equal(t, config.ErrBadRequest, err)
+
+	_, err = uc.Run(context.Background(), "", []string{"a", "b", "c"}, nil)
+	assert.Equal(t, config.ErrBadRequest, err)
+}
+
+func TestBacktestUseCase_LoadOutcomes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		input       string
+		mockSetup   func(repo *mocks.RepaymentOutcomeRepository)
+		expectedN   int
+		expectedErr error
+	}{
+		{
+			name:  "Valid outcomes are stored",
+			input: `[{"loanId": "L1", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "Repaid"}]`,
+			mockSetup: func(repo *mocks.RepaymentOutcomeRepository) {
+				repo.On("SaveOutcomes", ctx, mock.MatchedBy(func(outcomes []*domain.RepaymentOutcome) bool {
+					return len(outcomes) == 1 && outcomes[0].Outcome == domain.OutcomeRepaid
+				})).Return(nil).Once()
+			},
+			expectedN: 1,
+		},
+		{
+			name:        "Unknown outcome is rejected",
+			input:       `[{"loanId": "L1", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "written-off"}]`,
+			mockSetup:   func(repo *mocks.RepaymentOutcomeRepository) {},
+			expectedErr: config.ErrInvalidOutcome,
+		},
+		{
+			name:        "Invalid date is rejected",
+			input:       `[{"loanId": "L1", "customerId": "1", "decisionDate": "31/01/2025", "outcome": "late"}]`,
+			mockSetup:   func(repo *mocks.RepaymentOutcomeRepository) {},
+			expectedErr: config.ErrInvalidOutcome,
+		},
+		{
+			name: "Repeated loanId is rejected",
+			input: `[
+				{"loanId": "L1", "customerId": "1", "decisionDate": "2025-01-31", "outcome": "repaid"},
+				{"loanId": "L1", "customerId": "1", "decisionDate": "2025-02-28", "outcome": "late"}
+			]`,
+			mockSetup:   func(repo *mocks.RepaymentOutcomeRepository) {},
+			expectedErr: config.ErrInvalidOutcome,
+		},
+		{
+			name:        "Empty file is rejected",
+			input:       `[]`,
+			mockSetup:   func(repo *mocks.RepaymentOutcomeRepository) {},
+			expectedErr: config.ErrNoOutcomes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepaymentOutcomeRepository(t)
+			uc := NewBacktestUseCase(mocks.NewCustomerRepository(t), mocks.NewScorecardRepository(t), repo)
+			tt.mockSetup(repo)
+
+			n, err := uc.LoadOutcomes(ctx, bytes.NewReader([]byte(tt.input)))
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedN, n)
+		})
+	}
+}
+
+func TestDiscrimination(t *testing.T) {
+	outcomes := func(values ...string) []*domain.RepaymentOutcome {
+		var out []*domain.RepaymentOutcome
+		for _, v := range values {
+			out = append(out, &domain.RepaymentOutcome{Outcome: v})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		outcomes     []*domain.RepaymentOutcome
+		ratings      []float64
+		expectedGini float64
+		expectedKS   float64
+	}{
+		{
+			name:         "Perfect separation",
+			outcomes:     outcomes(domain.OutcomeDefaulted, domain.OutcomeRepaid, domain.OutcomeLate),
+			ratings:      []float64{2, 7, 8},
+			expectedGini: 1,
+			expectedKS:   1,
+		},
+		{
+			name:         "Ties carry no information",
+			outcomes:     outcomes(domain.OutcomeDefaulted, domain.OutcomeRepaid),
+			ratings:      []float64{5, 5},
+			expectedGini: 0,
+			expectedKS:   0,
+		},
+		{
+			name:         "Partial separation",
+			outcomes:     outcomes(domain.OutcomeDefaulted, domain.OutcomeRepaid, domain.OutcomeDefaulted, domain.OutcomeRepaid),
+			ratings:      []float64{2, 3, 4, 5},
+			expectedGini: 0.5,
+			expectedKS:   0.5,
+		},
+		{
+			name:         "No defaults",
+			outcomes:     outcomes(domain.OutcomeRepaid, domain.OutcomeLate),
+			ratings:      []float64{2, 3},
+			expectedGini: 0,
+			expectedKS:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gini, ks := discrimination(tt.outcomes, tt.ratings)
+			assert.Equal(t, tt.expectedGini, gini)
+			assert.Equal(t, tt.expectedKS, ks)
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRatingReasons limits how many of the weakest factors are explained.
+const maxRatingReasons = 2
+
+// factorReasons maps each factor to the reason given when it scores low.
+var factorReasons = map[string]domain.RatingReason{
+	domain.FactorCount:     {Code: domain.ReasonLowCount, Message: "Few transactions on the account"},
+	domain.FactorVolume:    {Code: domain.ReasonLowVolume, Message: "Low outgoing transaction volume"},
+	domain.FactorDuration:  {Code: domain.ReasonShortHistory, Message: "Short transaction history"},
+	domain.FactorStability: {Code: domain.ReasonUnstableBalance, Message: "Account balance fluctuates significantly"},
+	domain.FactorSalary:    {Code: domain.ReasonIrregularSalary, Message: "No regular monthly salary credits detected"},
+}
+
+// CalculateCustomerRating rates a customer on the transactions made up to and
+// including asOf; a zero asOf rates the customer as of now.
+func (uc *CustomerUseCase) CalculateCustomerRating(ctx context.Context, id string, asOf time.Time) (*domain.RatingResult, error) {
+	customer, err := uc.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find customer: %v", err)
+	}
+
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	return uc.rateAndStore(ctx, id, customer, asOf)
+}
+
+// RateAllCustomers rates every customer in valid_customers as of the given
+// date (now when zero) using at most workers concurrent ratings. Each rating
+// is stored as a snapshot; customers that cannot be rated are reported in the
+// summary rather than failing the run.
+func (uc *CustomerUseCase) RateAllCustomers(ctx context.Context, asOf time.Time, workers int) (*domain.RatingBatchSummary, error) {
+	started := time.Now()
+	if asOf.IsZero() {
+		asOf = started
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	customers, err := uc.customerRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load customers: %v", err)
+	}
+
+	type outcome struct {
+		customerID string
+		result     *domain.RatingResult
+		err        error
+	}
+	jobs := make(chan *domain.Customer)
+	outcomes := make(chan outcome)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for customer := range jobs {
+				id := strconv.Itoa(customer.ID)
+				result, err := uc.rateAndStore(ctx, id, customer, asOf)
+				outcomes <- outcome{customerID: id, result: result, err: err}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, customer := range customers {
+			select {
+			case jobs <- customer:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	summary := &domain.RatingBatchSummary{
+		AsOf:         asOf,
+		Total:        len(customers),
+		Distribution: make(map[string]int),
+		ByScorecard:  make(map[string]int),
+		Failures:     []domain.RatingFailure{},
+	}
+	var ratings []float64
+	for o := range outcomes {
+		if o.err != nil {
+			summary.Failures = append(summary.Failures, domain.RatingFailure{CustomerID: o.customerID, Error: o.err.Error()})
+			continue
+		}
+		ratings = append(ratings, o.result.Rating)
+		summary.ByScorecard[o.result.ScorecardVersion]++
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	summarizeRatings(summary, ratings)
+	summary.Failed = len(summary.Failures)
+	sort.Slice(summary.Failures, func(i, j int) bool {
+		return summary.Failures[i].CustomerID < summary.Failures[j].CustomerID
+	})
+	summary.Duration = time.Since(started).Round(time.Millisecond).String()
+	return summary, nil
+}
+
+// SimulateCustomerRating rates a customer as if the hypothetical transactions
+// in req had been made, merging them with the real history in memory. Nothing
+// is stored.
+func (uc *CustomerUseCase) SimulateCustomerRating(ctx context.Context, id string, req *domain.RatingSimulationRequest) (*domain.RatingResult, error) {
+	customer, err := uc.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find customer: %v", err)
+	}
+	transactions, err := uc.customerRepo.GetTransactionsByAccount(ctx, string(customer.AccountNo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %v", err)
+	}
+
+	now := time.Now()
+	asOf := now
+	simulated := *customer
+	merged := append([]*domain.Transaction(nil), transactions...)
+	for i, hypothetical := range req.Transactions {
+		date, err := time.Parse("2006-01-02", hypothetical.Date)
+		if err != nil || hypothetical.Amount <= 0 {
+			return nil, config.ErrInvalidTransactionPayload
+		}
+		counterparty := domain.AccountNo(strings.TrimSpace(hypothetical.Counterparty))
+		if counterparty == "" {
+			counterparty = "SIMULATED"
+		}
+		tx := &domain.Transaction{
+			TransactionID: fmt.Sprintf("SIM-%d", i+1),
+			Amount:        hypothetical.Amount,
+			Date:          date,
+			CreatedAt:     now,
+		}
+		switch hypothetical.Direction {
+		case "credit":
+			tx.FromAccount, tx.ToAccount = counterparty, customer.AccountNo
+			simulated.CustomerBalance += hypothetical.Amount
+		case "debit":
+			tx.FromAccount, tx.ToAccount = customer.AccountNo, counterparty
+			simulated.CustomerBalance -= hypothetical.Amount
+		default:
+			return nil, config.ErrInvalidTransactionPayload
+		}
+		merged = append(merged, tx)
+		if endOfDay := date.AddDate(0, 0, 1).Add(-time.Nanosecond); endOfDay.After(asOf) {
+			asOf = endOfDay
+		}
+	}
+	if req.AsOf != "" {
+		date, err := time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			return nil, config.ErrBadRequest
+		}
+		asOf = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	scorecard, err := uc.resolveScorecard(ctx, customer.ProductName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scorecard: %v", err)
+	}
+	result := uc.strategy.Rate(newRatingInput(id, &simulated, merged, scorecard, asOf))
+	result.Simulated = true
+	return result, nil
+}
+
+// RatingHistory returns the stored rating snapshots of a customer, oldest first.
+func (uc *CustomerUseCase) RatingHistory(ctx context.Context, id string) ([]*domain.RatingSnapshot, error) {
+	if _, err := uc.customerRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.ratingRepo.ListSnapshots(ctx, id)
+}
+
+// rateAndStore rates a customer and keeps the result as a snapshot.
+func (uc *CustomerUseCase) rateAndStore(ctx context.Context, id string, customer *domain.Customer, asOf time.Time) (*domain.RatingResult, error) {
+	result, err := uc.rateCustomer(ctx, id, customer, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.ratingRepo.SaveSnapshot(ctx, domain.NewRatingSnapshot(result)); err != nil {
+		return nil, fmt.Errorf("failed to save rating snapshot: %v", err)
+	}
+	return result, nil
+}
+
+// rateCustomer scores a customer with the scorecard for their product, using
+// only the transactions up to asOf and the balance the account had then.
+func (uc *CustomerUseCase) rateCustomer(ctx context.Context, id string, customer *domain.Customer, asOf time.Time) (*domain.RatingResult, error) {
+	transactions, err := uc.customerRepo.GetTransactionsByAccount(ctx, string(customer.AccountNo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %v", err)
+	}
+
+	scorecard, err := uc.resolveScorecard(ctx, customer.ProductName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scorecard: %v", err)
+	}
+
+	input := newRatingInput(id, customer, transactions, scorecard, asOf)
+	result := uc.strategy.Rate(input)
+	if uc.shadowStrategy != nil {
+		challenger := uc.shadowStrategy.Rate(input)
+		log.Printf("rating shadow: customer=%s asOf=%s %s=%.1f %s=%.1f diff=%.1f",
+			id, asOf.Format("2006-01-02"), result.Strategy, result.Rating,
+			challenger.Strategy, challenger.Rating, challenger.Rating-result.Rating)
+	}
+	return result, nil
+}
+
+// newRatingInput limits a customer's history to asOf and pairs it with the
+// scorecard to rate it with.
+func newRatingInput(id string, customer *domain.Customer, transactions []*domain.Transaction, scorecard *domain.Scorecard, asOf time.Time) *domain.RatingInput {
+	customer, transactions = ratingInputsAsOf(customer, transactions, asOf)
+	return &domain.RatingInput{
+		CustomerID:   id,
+		Customer:     customer,
+		Transactions: transactions,
+		Scorecard:    scorecard,
+		AsOf:         asOf,
+	}
+}
+
+// resolveScorecard picks the active scorecard for the customer's product,
+// falling back to the active default scorecard and then to the built-in one.
+func (uc *CustomerUseCase) resolveScorecard(ctx context.Context, productName string) (*domain.Scorecard, error) {
+	if productName != "" {
+		scorecard, err := uc.scorecardRepo.FindActive(ctx, productName)
+		if err != nil {
+			return nil, err
+		}
+		if scorecard != nil {
+			return scorecard, nil
+		}
+	}
+	scorecard, err := uc.scorecardRepo.FindActive(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if scorecard != nil {
+		return scorecard, nil
+	}
+	return domain.DefaultScorecard(), nil
+}
+
+// ratingInputsAsOf drops the transactions made after asOf and sets the
+// customer's balance to what it was at asOf. The returned customer is a copy.
+func ratingInputsAsOf(customer *domain.Customer, transactions []*domain.Transaction, asOf time.Time) (*domain.Customer, []*domain.Transaction) {
+	atDate := *customer
+	atDate.CustomerBalance = domain.NewBalanceHistory(customer.AccountNo, customer.CustomerBalance, transactions).BalanceAt(asOf)
+	included := make([]*domain.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if !tx.Date.After(asOf) {
+			included = append(included, tx)
+		}
+	}
+	return &atDate, included
+}
+
+// ratingFactors computes the raw value of every balance and activity factor:
+// transaction count, outgoing volume, days between first and last
+// transaction, and the standard deviation of the end-of-day balances.
+func ratingFactors(customer *domain.Customer, transactions []*domain.Transaction) map[string]float64 {
+	var totalVolume float64
+	for _, tx := range transactions {
+		if tx.FromAccount == customer.AccountNo {
+			totalVolume += tx.Amount
+		}
+	}
+
+	var firstDate, lastDate time.Time
+	for i, tx := range transactions {
+		if i == 0 {
+			firstDate = tx.Date
+			lastDate = tx.Date
+		} else {
+			if tx.Date.Before(firstDate) {
+				firstDate = tx.Date
+			}
+			if tx.Date.After(lastDate) {
+				lastDate = tx.Date
+			}
+		}
+	}
+	durationDays := lastDate.Sub(firstDate).Hours() / 24
+
+	var stdDev float64
+	daily := domain.NewBalanceHistory(customer.AccountNo, customer.CustomerBalance, transactions).Daily()
+	if len(daily) > 0 {
+		var mean, sumSquaredDiff float64
+		for _, day := range daily {
+			mean += day.Balance
+		}
+		mean /= float64(len(daily))
+		for _, day := range daily {
+			sumSquaredDiff += math.Pow(day.Balance-mean, 2)
+		}
+		stdDev = math.Sqrt(sumSquaredDiff / float64(len(daily)))
+	}
+
+	return map[string]float64{
+		domain.FactorCount:     float64(len(transactions)),
+		domain.FactorVolume:    totalVolume,
+		domain.FactorDuration:  durationDays,
+		domain.FactorStability: stdDev,
+	}
+}
+
+// normalizeFactor maps a raw factor value onto 0-1 using the factor's cap.
+// Stability is inverted: a larger balance deviation scores lower.
+func normalizeFactor(factor domain.ScorecardFactor, raw float64) float64 {
+	if factor.Name == domain.FactorStability {
+		return math.Max(1.0-raw/factor.Cap, 0.0)
+	}
+	return math.Min(raw/factor.Cap, 1.0)
+}
+
+// ratingReasons explains the lowest-scoring factors, weakest first. Factors
+// that already score full marks need no explanation.
+func ratingReasons(factors []domain.FactorBreakdown) []domain.RatingReason {
+	weakest := make([]domain.FactorBreakdown, 0, len(factors))
+	for _, factor := range factors {
+		if factor.NormalizedScore < 1 && factor.Weight > 0 {
+			weakest = append(weakest, factor)
+		}
+	}
+	sort.SliceStable(weakest, func(i, j int) bool {
+		return weakest[i].NormalizedScore < weakest[j].NormalizedScore
+	})
+
+	reasons := []domain.RatingReason{}
+	for _, factor := range weakest {
+		if len(reasons) == maxRatingReasons {
+			break
+		}
+		reason := factorReasons[factor.Name]
+		reason.Factor = factor.Name
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+// summarizeRatings fills in the rated count, descriptive statistics and the
+// one-point band distribution of a batch run.
+func summarizeRatings(summary *domain.RatingBatchSummary, ratings []float64) {
+	summary.Rated = len(ratings)
+	if len(ratings) == 0 {
+		return
+	}
+	sort.Float64s(ratings)
+
+	var total float64
+	for _, rating := range ratings {
+		total += rating
+		summary.Distribution[ratingBand(rating)]++
+	}
+	summary.Mean = roundTo(total/float64(len(ratings)), 2)
+	summary.Min = ratings[0]
+	summary.Max = ratings[len(ratings)-1]
+	mid := len(ratings) / 2
+	if len(ratings)%2 == 0 {
+		summary.Median = roundTo((ratings[mid-1]+ratings[mid])/2, 2)
+	} else {
+		summary.Median = ratings[mid]
+	}
+}
+
+// ratingBand names the one-point band a rating falls in; 10 belongs to "9-10".
+func ratingBand(rating float64) string {
+	band := int(math.Floor(rating))
+	if band >= 10 {
+		band = 9
+	}
+	return fmt.Sprintf("%d-%d", band, band+1)
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
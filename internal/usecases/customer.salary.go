@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"sort"
+)
+
+// Salary detection thresholds: credits from one source count as salary when
+// their amounts stay within salaryAmountTolerance of the source's median and
+// consecutive credits arrive between salaryMinGapDays and salaryMaxGapDays
+// apart.
+const (
+	salaryAmountTolerance = 0.2
+	salaryMinGapDays      = 25
+	salaryMaxGapDays      = 35
+)
+
+// salaryEstimate is the recurring inflow most likely to be the customer's
+// salary. Credits is the length of its monthly series, 0 when none was found.
+type salaryEstimate struct {
+	SourceAccount domain.AccountNo
+	MonthlyAmount float64
+	Credits       int
+}
+
+// detectSalary looks for recurring inflows of a similar amount arriving about
+// monthly from the same source account. Self-transfers are ignored. When
+// several sources qualify, the longest series wins, then the larger amount.
+func detectSalary(accountNo domain.AccountNo, transactions []*domain.Transaction) salaryEstimate {
+	inflows := make(map[domain.AccountNo][]*domain.Transaction)
+	for _, tx := range transactions {
+		if tx.ToAccount == accountNo && tx.FromAccount != accountNo && tx.Amount > 0 {
+			inflows[tx.FromAccount] = append(inflows[tx.FromAccount], tx)
+		}
+	}
+
+	var best salaryEstimate
+	for source, credits := range inflows {
+		estimate := monthlySeries(credits)
+		estimate.SourceAccount = source
+		if estimate.Credits > best.Credits ||
+			(estimate.Credits == best.Credits && estimate.MonthlyAmount > best.MonthlyAmount) {
+			best = estimate
+		}
+	}
+	if best.Credits == 0 {
+		return salaryEstimate{}
+	}
+	return best
+}
+
+// monthlySeries finds the longest run of similar credits from one source whose
+// gaps are all roughly a month, and estimates the salary as the run's median.
+func monthlySeries(credits []*domain.Transaction) salaryEstimate {
+	if len(credits) < 2 {
+		return salaryEstimate{}
+	}
+	median := medianAmount(credits)
+
+	var similar []*domain.Transaction
+	for _, tx := range credits {
+		if tx.Amount >= median*(1-salaryAmountTolerance) && tx.Amount <= median*(1+salaryAmountTolerance) {
+			similar = append(similar, tx)
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Date.Before(similar[j].Date) })
+
+	var best, run []*domain.Transaction
+	for i, tx := range similar {
+		if i > 0 {
+			gap := tx.Date.Sub(similar[i-1].Date).Hours() / 24
+			if gap < salaryMinGapDays || gap > salaryMaxGapDays {
+				run = nil
+			}
+		}
+		run = append(run, tx)
+		if len(run) > len(best) {
+			best = append([]*domain.Transaction(nil), run...)
+		}
+	}
+	if len(best) < 2 {
+		return salaryEstimate{}
+	}
+	return salaryEstimate{MonthlyAmount: roundTo(medianAmount(best), 2), Credits: len(best)}
+}
+
+func medianAmount(transactions []*domain.Transaction) float64 {
+	amounts := make([]float64, len(transactions))
+	for i, tx := range transactions {
+		amounts[i] = tx.Amount
+	}
+	sort.Float64s(amounts)
+	mid := len(amounts) / 2
+	if len(amounts)%2 == 0 {
+		return (amounts[mid-1] + amounts[mid]) / 2
+	}
+	return amounts[mid]
+}
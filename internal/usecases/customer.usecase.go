@@ -2,13 +2,13 @@ package usecases
 
 import (
 	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -18,20 +18,39 @@ import (
 )
 
 type CustomerUseCase struct {
-	customerRepo domain.CustomerRepository
-	validator    *validator.Validate
+	customerRepo   domain.CustomerRepository
+	scorecardRepo  domain.ScorecardRepository
+	ratingRepo     domain.RatingRepository
+	nameMatcher    domain.NameMatcher
+	enricher       domain.CustomerEnricher
+	strategy       domain.RatingStrategy
+	shadowStrategy domain.RatingStrategy
+	validator      *validator.Validate
 }
 
-func NewCustomerUseCase(customerRepo domain.CustomerRepository) *CustomerUseCase {
+func NewCustomerUseCase(customerRepo domain.CustomerRepository, scorecardRepo domain.ScorecardRepository, ratingRepo domain.RatingRepository, nameMatcher domain.NameMatcher, enricher domain.CustomerEnricher, strategy domain.RatingStrategy, shadowStrategy domain.RatingStrategy) *CustomerUseCase {
+	if strategy == nil {
+		strategy = NewScorecardStrategy()
+	}
 	return &CustomerUseCase{
-		customerRepo: customerRepo,
-		validator:    validator.New(),
+		customerRepo:   customerRepo,
+		scorecardRepo:  scorecardRepo,
+		ratingRepo:     ratingRepo,
+		nameMatcher:    nameMatcher,
+		enricher:       enricher,
+		strategy:       strategy,
+		shadowStrategy: shadowStrategy,
+		validator:      validator.New(),
 	}
 }
 
-func (uc *CustomerUseCase) ImportCustomers(ctx context.Context, file io.Reader) ([]*domain.Customer, []map[string]interface{}, error) {
+func (uc *CustomerUseCase) ImportCustomers(ctx context.Context, file io.Reader, dryRun bool) ([]*domain.Customer, []*domain.ValidationResult, error) {
 	var imported []*domain.Customer
-	var logs []map[string]interface{}
+	var logs []*domain.ValidationResult
+
+	// In dry-run mode nothing reaches valid_customers, so records accepted earlier
+	// in the same file are tracked here to report in-batch duplicates.
+	pending := make(map[string]bool)
 
 	data, err := io.ReadAll(file)
 	if err != nil {
@@ -41,22 +60,23 @@ func (uc *CustomerUseCase) ImportCustomers(ctx context.Context, file io.Reader)
 	log.Printf("Raw JSON input: %s", string(data))
 
 	var input []struct {
-		CustomerName string      `json:"customerName"`
-		AccountNo    interface{} `json:"accountNo"`
+		CustomerName    string      `json:"customerName"`
+		AccountNo       interface{} `json:"accountNo"`
+		Mobile          string      `json:"mobile"`
+		BranchName      string      `json:"branchName"`
+		BranchCode      string      `json:"branchCode"`
+		ProductName     string      `json:"productName"`
+		CustomerBalance float64     `json:"customerBalance"`
 	}
 	if err := json.Unmarshal(data, &input); err != nil {
 		return nil, nil, fmt.Errorf("invalid JSON format: %v", err)
 	}
 
 	for i, in := range input {
-		logEntry := map[string]interface{}{
-			"record_index": i + 1,
-			"verified":     false,
-			"errors":       []string{},
-		}
+		logEntry := domain.NewValidationResult(i + 1)
 
 		if in.CustomerName == "" {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "customer name is required")
+			logEntry.AddError(domain.FieldCustomerName, domain.CodeRequired, "customer name is required")
 		}
 
 		accountNoStr := ""
@@ -66,106 +86,266 @@ func (uc *CustomerUseCase) ImportCustomers(ctx context.Context, file io.Reader)
 		case string:
 			accountNoStr = v
 		default:
-			logEntry["errors"] = append(logEntry["errors"].([]string), "account number is in invalid format/type")
-			logEntry["attempted_name"] = in.CustomerName
-			logEntry["attempted_account_no"] = fmt.Sprintf("%v", in.AccountNo)
+			logEntry.AddError(domain.FieldAccountNo, domain.CodeInvalidFormat, "account number is in invalid format/type")
+			logEntry.AttemptedName = in.CustomerName
+			logEntry.AttemptedAccountNo = fmt.Sprintf("%v", in.AccountNo)
 			logs = append(logs, logEntry)
 			continue
 		}
 
 		if accountNoStr == "" {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "account number is required")
+			logEntry.AddError(domain.FieldAccountNo, domain.CodeRequired, "account number is required")
 		}
 
 		trimmedName := strings.TrimSpace(in.CustomerName)
 		strippedAccount := strings.TrimLeft(accountNoStr, "0")
 
 		if strippedAccount == "" || !isNumeric(strippedAccount) {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "account number is in invalid format/type")
+			logEntry.AddError(domain.FieldAccountNo, domain.CodeInvalidFormat, "account number is in invalid format/type")
 		}
 
-		verified := true
 		var normalized *domain.Customer
 
-		if len(logEntry["errors"].([]string)) == 0 {
+		if !logEntry.HasErrors() {
 
+			verifiedName := trimmedName
 			existing, err := uc.customerRepo.FindByNameAndAccountNo(ctx, trimmedName, accountNoStr)
 			if err != nil {
-				logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("database error: %v", err))
-				verified = false
+				logEntry.AddError("", domain.CodeDatabaseError, fmt.Sprintf("database error: %v", err))
 			} else if existing == nil {
-				logEntry["errors"] = append(logEntry["errors"].([]string), "name or account number does not match existing records in customers table")
-				verified = false
-			} else {
+				existing = uc.fuzzyMatch(ctx, logEntry, trimmedName, accountNoStr, dryRun)
+				if existing != nil {
+					verifiedName = strings.TrimSpace(existing.CustomerName)
+				}
+			}
 
-				duplicate, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, trimmedName, accountNoStr)
+			if existing != nil {
+
+				duplicate, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, verifiedName, accountNoStr)
+				pendingKey := strings.ToLower(verifiedName) + "|" + strippedAccount
 				if err != nil {
-					logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("error checking duplicates in valid_customers: %v", err))
-					verified = false
-				} else if duplicate != nil {
-					logEntry["errors"] = append(logEntry["errors"].([]string), "record already exists in valid_customers")
-					verified = false
+					logEntry.AddError("", domain.CodeDatabaseError, fmt.Sprintf("error checking duplicates in valid_customers: %v", err))
+				} else if duplicate != nil || pending[pendingKey] {
+					logEntry.AddError(domain.FieldAccountNo, domain.CodeDuplicateRecord, "record already exists in valid_customers")
 				} else {
 
-					normalized = &domain.Customer{
-						CustomerId:      fmt.Sprintf("CUST-%s", uuid.New().String()[:8]),
-						CustomerName:    trimmedName,
-						AccountNo:       domain.AccountNo(accountNoStr),
-						Mobile:          "",
-						BranchName:      "",
-						BranchCode:      "",
-						ProductName:     "",
-						CustomerBalance: 0,
-						CreatedAt:       time.Now(),
-						UpdatedAt:       time.Now(),
-					}
+					normalized = newValidCustomer(verifiedName, accountNoStr)
+					uc.enricher.Enrich(normalized, &domain.Customer{
+						Mobile:          strings.TrimSpace(in.Mobile),
+						BranchName:      strings.TrimSpace(in.BranchName),
+						BranchCode:      strings.TrimSpace(in.BranchCode),
+						ProductName:     strings.TrimSpace(in.ProductName),
+						CustomerBalance: in.CustomerBalance,
+					}, existing)
 
 					if err := uc.validator.Struct(normalized); err != nil {
-						logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("validation failed: %v", err))
-						verified = false
+						logEntry.AddError("", domain.CodeValidationFailed, fmt.Sprintf("validation failed: %v", err))
+					} else if dryRun {
+						pending[pendingKey] = true
+						imported = append(imported, normalized)
 					} else {
 						_, err := uc.customerRepo.Create(ctx, normalized)
 						if err != nil {
-							logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("failed to save to valid_customers: %v", err))
-							verified = false
+							logEntry.AddError("", domain.CodeSaveFailed, fmt.Sprintf("failed to save to valid_customers: %v", err))
 						} else {
 							imported = append(imported, normalized)
 						}
 					}
 				}
 			}
-		} else {
-			verified = false
 		}
 
-		logEntry["verified"] = verified
-		if verified {
-			logEntry["normalized_record"] = normalized
+		logEntry.Verified = !logEntry.HasErrors()
+		if logEntry.Verified {
+			logEntry.NormalizedRecord = normalized
 		} else {
-			logEntry["attempted_name"] = in.CustomerName
-			logEntry["attempted_account_no"] = accountNoStr
+			logEntry.AttemptedName = in.CustomerName
+			logEntry.AttemptedAccountNo = accountNoStr
 		}
 
 		logs = append(logs, logEntry)
 	}
 
-	if len(imported) == 0 {
+	if len(imported) == 0 && !dryRun {
 		return nil, logs, errors.New("no valid customers imported; see logs for details")
 	}
 
 	for _, l := range logs {
-		if !l["verified"].(bool) {
+		if !l.Verified {
 			fmt.Printf("Unverified record %d: attempted_name=%s, attempted_account_no=%s, errors=%v\n",
-				l["record_index"], l["attempted_name"], l["attempted_account_no"], l["errors"])
+				l.RecordIndex, l.AttemptedName, l.AttemptedAccountNo, l.Errors)
 		}
 	}
 
 	return imported, logs, nil
 }
 
-func (uc *CustomerUseCase) ImportTransactions(ctx context.Context, file io.Reader, allowOverdraft bool) ([]*domain.Transaction, []map[string]interface{}, error) {
+// fuzzyMatch is consulted when no exact name/account match exists. It returns the
+// customers row when the submitted name is close enough to accept outright,
+// queues a review for mid-confidence matches, and otherwise records why the
+// record was rejected. A name already waiting for review against the same
+// customers row reuses that review, so re-importing a file queues nothing new.
+func (uc *CustomerUseCase) fuzzyMatch(ctx context.Context, logEntry *domain.ValidationResult, name string, accountNo string, dryRun bool) *domain.Customer {
+	byAccount, err := uc.customerRepo.FindByAccountNo(ctx, accountNo)
+	if err != nil {
+		logEntry.AddError("", domain.CodeDatabaseError, fmt.Sprintf("database error: %v", err))
+		return nil
+	}
+	if byAccount == nil {
+		logEntry.AddError(domain.FieldAccountNo, domain.CodeAccountNotFound, "account number does not match existing records in customers table")
+		return nil
+	}
+
+	score, decision := uc.nameMatcher.Match(name, byAccount.CustomerName)
+	logEntry.MatchConfidence = score
+
+	switch decision {
+	case domain.MatchAccepted:
+		return byAccount
+	case domain.MatchReview:
+		if !dryRun {
+			review, err := uc.queueReview(ctx, &domain.CustomerReview{
+				SubmittedName:     name,
+				AccountNo:         domain.AccountNo(accountNo),
+				MatchedCustomerID: byAccount.ID,
+				MatchedName:       strings.TrimSpace(byAccount.CustomerName),
+				Confidence:        score,
+				Status:            domain.ReviewStatusPending,
+			})
+			if err != nil {
+				logEntry.AddError("", domain.CodeSaveFailed, fmt.Sprintf("failed to queue name match for review: %v", err))
+				return nil
+			}
+			logEntry.ReviewID = review.ID
+		}
+		logEntry.AddError(domain.FieldCustomerName, domain.CodePendingReview,
+			fmt.Sprintf("customer name is a possible match (confidence %.2f) and needs manual review", score))
+	default:
+		logEntry.AddError(domain.FieldCustomerName, domain.CodeNameMismatch, "customer name does not match the account holder in customers table")
+	}
+	return nil
+}
+
+func (uc *CustomerUseCase) queueReview(ctx context.Context, review *domain.CustomerReview) (*domain.CustomerReview, error) {
+	pending, err := uc.customerRepo.FindPendingReviews(ctx, review.MatchedCustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range pending {
+		if existing.SubmittedName == review.SubmittedName {
+			return existing, nil
+		}
+	}
+	return uc.customerRepo.CreateReview(ctx, review)
+}
+
+func (uc *CustomerUseCase) ListReviews(ctx context.Context, status string) ([]*domain.CustomerReview, error) {
+	return uc.customerRepo.ListReviews(ctx, status)
+}
+
+// ApproveReview promotes a queued match into valid_customers under the name
+// held in the customers table. Other reviews still pending against the same
+// customers row would promote the same record, so they are approved with it.
+func (uc *CustomerUseCase) ApproveReview(ctx context.Context, id uint, reviewerID uint, note string) (*domain.Customer, error) {
+	review, err := uc.customerRepo.FindReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != domain.ReviewStatusPending {
+		return nil, config.ErrReviewAlreadyResolved
+	}
+
+	duplicate, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, review.MatchedName, string(review.AccountNo))
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return nil, config.ErrCustomerAlreadyExists
+	}
+
+	master, err := uc.customerRepo.FindByAccountNo(ctx, string(review.AccountNo))
+	if err != nil {
+		return nil, err
+	}
+
+	customer := newValidCustomer(review.MatchedName, string(review.AccountNo))
+	uc.enricher.Enrich(customer, nil, master)
+	if err := uc.validator.Struct(customer); err != nil {
+		return nil, config.ErrInvalidCustomerDetails
+	}
+	if _, err := uc.customerRepo.Create(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.resolveReview(ctx, review, domain.ReviewStatusApproved, reviewerID, note); err != nil {
+		return nil, err
+	}
+
+	siblings, err := uc.customerRepo.FindPendingReviews(ctx, review.MatchedCustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sibling := range siblings {
+		if sibling.ID == review.ID {
+			continue
+		}
+		if _, err := uc.resolveReview(ctx, sibling, domain.ReviewStatusApproved, reviewerID, fmt.Sprintf("resolved with review %d", review.ID)); err != nil {
+			return nil, err
+		}
+	}
+	return customer, nil
+}
+
+func (uc *CustomerUseCase) RejectReview(ctx context.Context, id uint, reviewerID uint, note string) (*domain.CustomerReview, error) {
+	review, err := uc.customerRepo.FindReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != domain.ReviewStatusPending {
+		return nil, config.ErrReviewAlreadyResolved
+	}
+	return uc.resolveReview(ctx, review, domain.ReviewStatusRejected, reviewerID, note)
+}
+
+func (uc *CustomerUseCase) resolveReview(ctx context.Context, review *domain.CustomerReview, status string, reviewerID uint, note string) (*domain.CustomerReview, error) {
+	now := time.Now()
+	review.Status = status
+	review.ReviewedBy = reviewerID
+	review.ReviewedAt = &now
+	review.Note = note
+	return uc.customerRepo.UpdateReview(ctx, review)
+}
+
+func newValidCustomer(name string, accountNo string) *domain.Customer {
+	return &domain.Customer{
+		CustomerId:   fmt.Sprintf("CUST-%s", uuid.New().String()[:8]),
+		CustomerName: name,
+		AccountNo:    domain.AccountNo(accountNo),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+func (uc *CustomerUseCase) ImportTransactions(ctx context.Context, file io.Reader, allowOverdraft bool, dryRun bool) ([]*domain.Transaction, []*domain.ValidationResult, error) {
 	var transactions []*domain.Transaction
-	var logs []map[string]interface{}
+	var logs []*domain.ValidationResult
+
+	// In dry-run mode balances are never written back, so each account is loaded
+	// once and its projected balance carried through the rest of the file.
+	projected := make(map[string]*domain.Customer)
+	findAccount := func(accountNo string) (*domain.Customer, error) {
+		if dryRun {
+			if customer, ok := projected[accountNo]; ok {
+				return customer, nil
+			}
+		}
+		customer, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, "", accountNo)
+		if err != nil || customer == nil || !dryRun {
+			return customer, err
+		}
+		projected[accountNo] = customer
+		return customer, nil
+	}
 
 	data, err := io.ReadAll(file)
 	if err != nil {
@@ -182,70 +362,60 @@ func (uc *CustomerUseCase) ImportTransactions(ctx context.Context, file io.Reade
 		return nil, nil, fmt.Errorf("invalid JSON format: %v", err)
 	}
 
-	
 	for i, in := range input {
-		logEntry := map[string]interface{}{
-			"record_index": i + 1,
-			"verified":     false,
-			"errors":       []string{},
+		logEntry := domain.NewValidationResult(i + 1)
+		rejected := func() {
+			logEntry.AttemptedFromAccount = in.FromAccount
+			logEntry.AttemptedToAccount = in.ToAccount
+			logEntry.AttemptedAmount = in.Amount
+			logs = append(logs, logEntry)
 		}
 
-		
-		if in.FromAccount == "" || in.ToAccount == "" {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "fromAccount and toAccount are required")
+		if in.FromAccount == "" {
+			logEntry.AddError(domain.FieldFromAccount, domain.CodeRequired, "fromAccount and toAccount are required")
+		}
+		if in.ToAccount == "" {
+			logEntry.AddError(domain.FieldToAccount, domain.CodeRequired, "fromAccount and toAccount are required")
 		}
 		if in.Amount <= 0 {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "amount must be positive")
+			logEntry.AddError(domain.FieldAmount, domain.CodeInvalidAmount, "amount must be positive")
 		}
 
-		
 		parsedDate, err := time.Parse("2006-01-02", in.Date)
 		if err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("invalid date format: %v", err))
+			logEntry.AddError(domain.FieldDate, domain.CodeInvalidDate, fmt.Sprintf("invalid date format: %v", err))
 		}
 
-		if len(logEntry["errors"].([]string)) > 0 {
-			logEntry["attempted_from_account"] = in.FromAccount
-			logEntry["attempted_to_account"] = in.ToAccount
-			logEntry["attempted_amount"] = in.Amount
-			logs = append(logs, logEntry)
+		if logEntry.HasErrors() {
+			rejected()
 			continue
 		}
 
-		
-		fromCustomer, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, "", in.FromAccount)
+		fromCustomer, err := findAccount(in.FromAccount)
 		if err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("error checking fromAccount: %v", err))
+			logEntry.AddError(domain.FieldFromAccount, domain.CodeDatabaseError, fmt.Sprintf("error checking fromAccount: %v", err))
 		} else if fromCustomer == nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "fromAccount not found in valid_customers")
+			logEntry.AddError(domain.FieldFromAccount, domain.CodeAccountNotFound, "fromAccount not found in valid_customers")
 		}
 
-		toCustomer, err := uc.customerRepo.CheckDuplicateInValidCustomers(ctx, "", in.ToAccount)
+		toCustomer, err := findAccount(in.ToAccount)
 		if err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("error checking toAccount: %v", err))
+			logEntry.AddError(domain.FieldToAccount, domain.CodeDatabaseError, fmt.Sprintf("error checking toAccount: %v", err))
 		} else if toCustomer == nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "toAccount not found in valid_customers")
+			logEntry.AddError(domain.FieldToAccount, domain.CodeAccountNotFound, "toAccount not found in valid_customers")
 		}
 
-		if len(logEntry["errors"].([]string)) > 0 {
-			logEntry["attempted_from_account"] = in.FromAccount
-			logEntry["attempted_to_account"] = in.ToAccount
-			logEntry["attempted_amount"] = in.Amount
-			logs = append(logs, logEntry)
+		if logEntry.HasErrors() {
+			rejected()
 			continue
 		}
 
-		
 		if !allowOverdraft && fromCustomer.CustomerBalance < in.Amount {
-			logEntry["errors"] = append(logEntry["errors"].([]string), "insufficient balance for fromAccount")
-			logEntry["attempted_from_account"] = in.FromAccount
-			logEntry["attempted_to_account"] = in.ToAccount
-			logEntry["attempted_amount"] = in.Amount
-			logs = append(logs, logEntry)
+			logEntry.AddError(domain.FieldAmount, domain.CodeInsufficientBalance, "insufficient balance for fromAccount")
+			rejected()
 			continue
 		}
 
-		
 		transaction := &domain.Transaction{
 			TransactionID: fmt.Sprintf("TXN-%s", uuid.New().String()[:8]),
 			FromAccount:   domain.AccountNo(in.FromAccount),
@@ -256,209 +426,140 @@ func (uc *CustomerUseCase) ImportTransactions(ctx context.Context, file io.Reade
 			UpdatedAt:     time.Now(),
 		}
 
-		
 		if err := uc.validator.Struct(transaction); err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("validation failed: %v", err))
+			logEntry.AddError("", domain.CodeValidationFailed, fmt.Sprintf("validation failed: %v", err))
 			logs = append(logs, logEntry)
 			continue
 		}
 
-	
+		if dryRun {
+			fromCustomer.CustomerBalance -= in.Amount
+			toCustomer.CustomerBalance += in.Amount
+			logEntry.Verified = true
+			logEntry.Transaction = transaction
+			logs = append(logs, logEntry)
+			transactions = append(transactions, transaction)
+			continue
+		}
+
 		_, err = uc.customerRepo.CreateTransaction(ctx, transaction)
 		if err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("failed to save transaction: %v", err))
+			logEntry.AddError("", domain.CodeSaveFailed, fmt.Sprintf("failed to save transaction: %v", err))
 			logs = append(logs, logEntry)
 			continue
 		}
 
-		
 		fromCustomer.CustomerBalance -= in.Amount
 		toCustomer.CustomerBalance += in.Amount
 		if _, err := uc.customerRepo.Update(ctx, fromCustomer); err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("failed to update fromCustomer balance: %v", err))
+			logEntry.AddError(domain.FieldFromAccount, domain.CodeSaveFailed, fmt.Sprintf("failed to update fromCustomer balance: %v", err))
 			logs = append(logs, logEntry)
 			continue
 		}
 		if _, err := uc.customerRepo.Update(ctx, toCustomer); err != nil {
-			logEntry["errors"] = append(logEntry["errors"].([]string), fmt.Sprintf("failed to update toCustomer balance: %v", err))
+			logEntry.AddError(domain.FieldToAccount, domain.CodeSaveFailed, fmt.Sprintf("failed to update toCustomer balance: %v", err))
 			logs = append(logs, logEntry)
 			continue
 		}
 
-		logEntry["verified"] = true
-		logEntry["transaction"] = transaction
+		logEntry.Verified = true
+		logEntry.Transaction = transaction
 		logs = append(logs, logEntry)
 		transactions = append(transactions, transaction)
 	}
 
-	
 	customers, err := uc.customerRepo.FindAll(ctx)
 	if err != nil {
 		return transactions, logs, fmt.Errorf("failed to fetch customers for synthetic transactions: %v", err)
 	}
 
+	syntheticFailure := func(code domain.ValidationErrorCode, message string) {
+		entry := domain.NewValidationResult(0)
+		entry.Synthetic = true
+		entry.AddError("", code, message)
+		logs = append(logs, entry)
+	}
+
 	for _, customer := range customers {
+		if dryRun {
+			if current, ok := projected[string(customer.AccountNo)]; ok {
+				customer = current
+			}
+		}
 		hasTransactions, err := uc.customerRepo.HasTransactions(ctx, string(customer.AccountNo))
 		if err != nil {
-			logs = append(logs, map[string]interface{}{
-				"record_index": 0,
-				"verified":     false,
-				"errors":       []string{fmt.Sprintf("error checking transactions for customer %s: %v", customer.AccountNo, err)},
-			})
+			syntheticFailure(domain.CodeDatabaseError, fmt.Sprintf("error checking transactions for customer %s: %v", customer.AccountNo, err))
 			continue
 		}
 
+		if !hasTransactions && dryRun {
+			for _, tx := range transactions {
+				if tx.FromAccount == customer.AccountNo || tx.ToAccount == customer.AccountNo {
+					hasTransactions = true
+					break
+				}
+			}
+		}
+
 		if !hasTransactions {
-			
+
 			syntheticTransaction := &domain.Transaction{
 				TransactionID: fmt.Sprintf("TXN-%s", uuid.New().String()[:8]),
 				FromAccount:   customer.AccountNo,
-				ToAccount:     domain.AccountNo("SYNTHETIC-" + string(customer.AccountNo)),
-				Amount:        100.0, 
+				ToAccount:     domain.AccountNo(domain.SyntheticAccountPrefix + string(customer.AccountNo)),
+				Amount:        100.0,
 				Date:          time.Now(),
 				CreatedAt:     time.Now(),
 				UpdatedAt:     time.Now(),
 			}
 
-			
 			if err := uc.validator.Struct(syntheticTransaction); err != nil {
-				logs = append(logs, map[string]interface{}{
-					"record_index": 0,
-					"verified":     false,
-					"errors":       []string{fmt.Sprintf("validation failed for synthetic transaction: %v", err)},
-				})
+				syntheticFailure(domain.CodeValidationFailed, fmt.Sprintf("validation failed for synthetic transaction: %v", err))
 				continue
 			}
 
-			
-			_, err = uc.customerRepo.CreateTransaction(ctx, syntheticTransaction)
-			if err != nil {
-				logs = append(logs, map[string]interface{}{
-					"record_index": 0,
-					"verified":     false,
-					"errors":       []string{fmt.Sprintf("failed to save synthetic transaction: %v", err)},
-				})
-				continue
-			}
+			if dryRun {
+				if !allowOverdraft && customer.CustomerBalance < syntheticTransaction.Amount {
+					syntheticFailure(domain.CodeInsufficientBalance, "insufficient balance for synthetic transaction")
+					continue
+				}
+			} else {
+				_, err = uc.customerRepo.CreateTransaction(ctx, syntheticTransaction)
+				if err != nil {
+					syntheticFailure(domain.CodeSaveFailed, fmt.Sprintf("failed to save synthetic transaction: %v", err))
+					continue
+				}
 
-			
-			if !allowOverdraft && customer.CustomerBalance < syntheticTransaction.Amount {
-				logs = append(logs, map[string]interface{}{
-					"record_index": 0,
-					"verified":     false,
-					"errors":       []string{"insufficient balance for synthetic transaction"},
-				})
-				continue
-			}
+				if !allowOverdraft && customer.CustomerBalance < syntheticTransaction.Amount {
+					syntheticFailure(domain.CodeInsufficientBalance, "insufficient balance for synthetic transaction")
+					continue
+				}
 
-			customer.CustomerBalance -= syntheticTransaction.Amount
-			if _, err := uc.customerRepo.Update(ctx, customer); err != nil {
-				logs = append(logs, map[string]interface{}{
-					"record_index": 0,
-					"verified":     false,
-					"errors":       []string{fmt.Sprintf("failed to update customer balance for synthetic transaction: %v", err)},
-				})
-				continue
+				customer.CustomerBalance -= syntheticTransaction.Amount
+				if _, err := uc.customerRepo.Update(ctx, customer); err != nil {
+					syntheticFailure(domain.CodeSaveFailed, fmt.Sprintf("failed to update customer balance for synthetic transaction: %v", err))
+					continue
+				}
 			}
 
 			transactions = append(transactions, syntheticTransaction)
-			logs = append(logs, map[string]interface{}{
-				"record_index": 0,
-				"verified":     true,
-				"transaction":  syntheticTransaction,
-				"synthetic":    true,
+			logs = append(logs, &domain.ValidationResult{
+				RecordIndex: 0,
+				Verified:    true,
+				Errors:      []domain.FieldError{},
+				Transaction: syntheticTransaction,
+				Synthetic:   true,
 			})
 		}
 	}
 
-	if len(transactions) == 0 {
+	if len(transactions) == 0 && !dryRun {
 		return nil, logs, errors.New("no valid transactions imported; see logs for details")
 	}
 
 	return transactions, logs, nil
 }
 
-func (uc *CustomerUseCase) CalculateCustomerRating(ctx context.Context, id string) (float64, error) {
-	customer, err := uc.customerRepo.FindByID(ctx, id)
-	if err != nil {
-		return 0, fmt.Errorf("failed to find customer: %v", err)
-	}
-
-	transactions, err := uc.customerRepo.GetTransactionsByAccount(ctx, string(customer.AccountNo))
-	if err != nil {
-		return 0, fmt.Errorf("failed to fetch transactions: %v", err)
-	}
-
-	if len(transactions) == 0 {
-		return 1.0, nil 
-	}
-
-	countScore := math.Min(float64(len(transactions))/10.0, 1.0) 
-
-
-	var totalVolume float64
-	for _, tx := range transactions {
-		if tx.FromAccount == customer.AccountNo {
-			totalVolume += tx.Amount
-		}
-	}
-	volumeScore := math.Min(totalVolume/10000.0, 1.0) 
-
-	
-	var firstDate, lastDate time.Time
-	for i, tx := range transactions {
-		if i == 0 {
-			firstDate = tx.Date
-			lastDate = tx.Date
-		} else {
-			if tx.Date.Before(firstDate) {
-				firstDate = tx.Date
-			}
-			if tx.Date.After(lastDate) {
-				lastDate = tx.Date
-			}
-		}
-	}
-	durationDays := lastDate.Sub(firstDate).Hours() / 24
-	durationScore := math.Min(durationDays/365.0, 1.0) 
-
-	
-	var balances []float64
-	currentBalance := customer.CustomerBalance
-	for i := len(transactions) - 1; i >= 0; i-- {
-		tx := transactions[i]
-		if tx.FromAccount == customer.AccountNo {
-			currentBalance += tx.Amount
-		} else if tx.ToAccount == customer.AccountNo {
-			currentBalance -= tx.Amount
-		}
-		balances = append(balances, currentBalance)
-	}
-
-	
-	var mean, sumSquaredDiff float64
-	for _, balance := range balances {
-		mean += balance
-	}
-	mean /= float64(len(balances))
-	for _, balance := range balances {
-		sumSquaredDiff += math.Pow(balance-mean, 2)
-	}
-	stdDev := math.Sqrt(sumSquaredDiff / float64(len(balances)))
-	stabilityScore := math.Max(1.0-stdDev/10000.0, 0.0) 
-
-	
-	totalRating := (0.3*countScore + 0.3*volumeScore + 0.2*durationScore + 0.2*stabilityScore) * 10.0
-	totalRating = math.Round(totalRating*10) / 10 
-	if totalRating < 1.0 {
-		totalRating = 1.0
-	} else if totalRating > 10.0 {
-		totalRating = 10.0
-	}
-
-	return totalRating, nil
-}
-
 func (uc *CustomerUseCase) GetCustomer(ctx context.Context, id string) (*domain.Customer, error) {
 	return uc.customerRepo.FindByID(ctx, id)
 }
@@ -3,9 +3,14 @@ package usecases
 import (
 	"SalaryAdvance/internal/domain"
 	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/internal/services"
+	"SalaryAdvance/pkg/config"
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"os"
 	"testing"
 	"time"
 
@@ -16,14 +21,15 @@ import (
 func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := mocks.NewCustomerRepository(t)
-	uc := NewCustomerUseCase(mockRepo)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
 
 	tests := []struct {
 		name              string
 		inputJSON         string
+		dryRun            bool
 		mockSetup         func()
 		expectedCustomers []*domain.Customer
-		expectedLogs      []map[string]interface{}
+		expectedLogs      []*domain.ValidationResult
 		expectedErr       error
 	}{
 		{
@@ -50,16 +56,14 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 				{CustomerId: "CUST-12345678", CustomerName: "John Doe", AccountNo: "12345"},
 				{CustomerId: "CUST-87654321", CustomerName: "Jane Smith", AccountNo: "67890"},
 			},
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index":      1,
-					"verified":          true,
-					"normalized_record": mock.Anything,
+					RecordIndex: 1,
+					Verified:    true,
 				},
 				{
-					"record_index":      2,
-					"verified":          true,
-					"normalized_record": mock.Anything,
+					RecordIndex: 2,
+					Verified:    true,
 				},
 			},
 			expectedErr: nil,
@@ -79,13 +83,13 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 			]`,
 			mockSetup:         func() {},
 			expectedCustomers: nil,
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index":         1,
-					"verified":             false,
-					"errors":               []string{"customer name is required"},
-					"attempted_name":       "",
-					"attempted_account_no": "12345",
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeRequired}},
+					AttemptedName:      "",
+					AttemptedAccountNo: "12345",
 				},
 			},
 			expectedErr: errors.New("no valid customers imported; see logs for details"),
@@ -102,13 +106,13 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 					Return(&domain.Customer{CustomerId: "CUST-12345678"}, nil).Once()
 			},
 			expectedCustomers: nil,
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index":         1,
-					"verified":             false,
-					"errors":               []string{"record already exists in valid_customers"},
-					"attempted_name":       "John Doe",
-					"attempted_account_no": "12345",
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeDuplicateRecord}},
+					AttemptedName:      "John Doe",
+					AttemptedAccountNo: "12345",
 				},
 			},
 			expectedErr: errors.New("no valid customers imported; see logs for details"),
@@ -120,24 +124,175 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 			]`,
 			mockSetup:         func() {},
 			expectedCustomers: nil,
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index":         1,
-					"verified":             false,
-					"errors":               []string{"account number is in invalid format/type"},
-					"attempted_name":       "John Doe",
-					"attempted_account_no": "abc",
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeInvalidFormat}},
+					AttemptedName:      "John Doe",
+					AttemptedAccountNo: "abc",
 				},
 			},
 			expectedErr: errors.New("no valid customers imported; see logs for details"),
 		},
+		{
+			name: "Name mismatch and unknown account",
+			inputJSON: `[
+				{"customerName": "John Doe", "accountNo": "12345"},
+				{"customerName": "Jane Smith", "accountNo": "99999"}
+			]`,
+			mockSetup: func() {
+				mockRepo.On("FindByNameAndAccountNo", ctx, "John Doe", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByAccountNo", ctx, "12345").
+					Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("FindByNameAndAccountNo", ctx, "Jane Smith", "99999").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByAccountNo", ctx, "99999").
+					Return(nil, nil).Once()
+			},
+			expectedCustomers: nil,
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeNameMismatch}},
+					AttemptedName:      "John Doe",
+					AttemptedAccountNo: "12345",
+				},
+				{
+					RecordIndex:        2,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeAccountNotFound}},
+					AttemptedName:      "Jane Smith",
+					AttemptedAccountNo: "99999",
+				},
+			},
+			expectedErr: errors.New("no valid customers imported; see logs for details"),
+		},
+		{
+			name: "Fuzzy name match is verified under the master name",
+			inputJSON: `[
+				{"customerName": "Abebe  Kebde", "accountNo": "12345"}
+			]`,
+			mockSetup: func() {
+				mockRepo.On("FindByNameAndAccountNo", ctx, "Abebe  Kebde", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByAccountNo", ctx, "12345").
+					Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("CheckDuplicateInValidCustomers", ctx, "Abebe Kebede", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Customer")).
+					Return(&domain.Customer{}, nil).Once()
+			},
+			expectedCustomers: []*domain.Customer{
+				{CustomerName: "Abebe Kebede", AccountNo: "12345"},
+			},
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex: 1,
+					Verified:    true,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Mid-confidence name match is queued for review",
+			inputJSON: `[
+				{"customerName": "Abebe Kebe", "accountNo": "12345"}
+			]`,
+			mockSetup: func() {
+				mockRepo.On("FindByNameAndAccountNo", ctx, "Abebe Kebe", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByAccountNo", ctx, "12345").
+					Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("FindPendingReviews", ctx, 1).
+					Return([]*domain.CustomerReview{{ID: 5, SubmittedName: "Abebe K", MatchedCustomerID: 1, Status: domain.ReviewStatusPending}}, nil).Once()
+				mockRepo.On("CreateReview", ctx, mock.MatchedBy(func(r *domain.CustomerReview) bool {
+					return r.Status == domain.ReviewStatusPending && r.MatchedName == "Abebe Kebede" && r.SubmittedName == "Abebe Kebe"
+				})).Return(&domain.CustomerReview{ID: 7}, nil).Once()
+			},
+			expectedCustomers: nil,
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodePendingReview}},
+					AttemptedName:      "Abebe Kebe",
+					AttemptedAccountNo: "12345",
+					ReviewID:           7,
+				},
+			},
+			expectedErr: errors.New("no valid customers imported; see logs for details"),
+		},
+		{
+			name: "Re-import reuses the pending review",
+			inputJSON: `[
+				{"customerName": "Abebe Kebe", "accountNo": "12345"}
+			]`,
+			mockSetup: func() {
+				mockRepo.On("FindByNameAndAccountNo", ctx, "Abebe Kebe", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByAccountNo", ctx, "12345").
+					Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("FindPendingReviews", ctx, 1).
+					Return([]*domain.CustomerReview{{ID: 7, SubmittedName: "Abebe Kebe", MatchedCustomerID: 1, Status: domain.ReviewStatusPending}}, nil).Once()
+			},
+			expectedCustomers: nil,
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex:        1,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodePendingReview}},
+					AttemptedName:      "Abebe Kebe",
+					AttemptedAccountNo: "12345",
+					ReviewID:           7,
+				},
+			},
+			expectedErr: errors.New("no valid customers imported; see logs for details"),
+		},
+		{
+			name: "Dry run reports in-batch duplicates without saving",
+			inputJSON: `[
+				{"customerName": "John Doe", "accountNo": "12345"},
+				{"customerName": "john doe ", "accountNo": "012345"}
+			]`,
+			dryRun: true,
+			mockSetup: func() {
+				mockRepo.On("FindByNameAndAccountNo", ctx, "John Doe", "12345").
+					Return(&domain.Customer{ID: 1, CustomerName: "John Doe", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("CheckDuplicateInValidCustomers", ctx, "John Doe", "12345").
+					Return(nil, nil).Once()
+				mockRepo.On("FindByNameAndAccountNo", ctx, "john doe", "012345").
+					Return(&domain.Customer{ID: 1, CustomerName: "John Doe", AccountNo: "12345"}, nil).Once()
+				mockRepo.On("CheckDuplicateInValidCustomers", ctx, "john doe", "012345").
+					Return(nil, nil).Once()
+			},
+			expectedCustomers: []*domain.Customer{
+				{CustomerName: "John Doe", AccountNo: "12345"},
+			},
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex: 1,
+					Verified:    true,
+				},
+				{
+					RecordIndex:        2,
+					Verified:           false,
+					Errors:             []domain.FieldError{{Code: domain.CodeDuplicateRecord}},
+					AttemptedName:      "john doe ",
+					AttemptedAccountNo: "012345",
+				},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 			reader := bytes.NewReader([]byte(tt.inputJSON))
-			customers, logs, err := uc.ImportCustomers(ctx, reader)
+			customers, logs, err := uc.ImportCustomers(ctx, reader, tt.dryRun)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -160,18 +315,16 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 			if len(tt.expectedLogs) > 0 {
 				assert.Len(t, logs, len(tt.expectedLogs))
 				for i, expectedLog := range tt.expectedLogs {
-					assert.Equal(t, expectedLog["record_index"], logs[i]["record_index"])
-					assert.Equal(t, expectedLog["verified"], logs[i]["verified"])
-					if !expectedLog["verified"].(bool) {
-						assert.NotEmpty(t, logs[i]["errors"])
-						if expectedLog["attempted_name"] != nil {
-							assert.Equal(t, expectedLog["attempted_name"], logs[i]["attempted_name"])
-						}
-						if expectedLog["attempted_account_no"] != nil {
-							assert.Equal(t, expectedLog["attempted_account_no"], logs[i]["attempted_account_no"])
-						}
+					assert.Equal(t, expectedLog.RecordIndex, logs[i].RecordIndex)
+					assert.Equal(t, expectedLog.Verified, logs[i].Verified)
+					if !expectedLog.Verified {
+						assert.Equal(t, errorCodes(expectedLog.Errors), errorCodes(logs[i].Errors))
+						assert.Equal(t, expectedLog.AttemptedName, logs[i].AttemptedName)
+						assert.Equal(t, expectedLog.AttemptedAccountNo, logs[i].AttemptedAccountNo)
+						assert.Equal(t, expectedLog.ReviewID, logs[i].ReviewID)
 					} else {
-						assert.NotNil(t, logs[i]["normalized_record"])
+						assert.Empty(t, logs[i].Errors)
+						assert.NotNil(t, logs[i].NormalizedRecord)
 					}
 				}
 			} else {
@@ -181,18 +334,87 @@ func TestCustomerUseCase_ImportCustomers(t *testing.T) {
 	}
 }
 
+func TestCustomerUseCase_ImportCustomersEnrichment(t *testing.T) {
+	ctx := context.Background()
+	master := &domain.Customer{
+		ID:              1,
+		CustomerName:    "John Doe",
+		AccountNo:       "12345",
+		Mobile:          "0911000000",
+		BranchName:      "Bole",
+		BranchCode:      "BR01",
+		ProductName:     "Savings",
+		CustomerBalance: 2500,
+	}
+	input := `[{"customerName": "John Doe", "accountNo": "12345", "mobile": "0922000000"}]`
+
+	tests := []struct {
+		name            string
+		precedence      map[string]string
+		expectedMobile  string
+		expectedSources domain.FieldSources
+	}{
+		{
+			name:           "Master data wins by default",
+			precedence:     nil,
+			expectedMobile: "0911000000",
+			expectedSources: domain.FieldSources{
+				"mobile":          domain.FieldSourceMaster,
+				"branchName":      domain.FieldSourceMaster,
+				"branchCode":      domain.FieldSourceMaster,
+				"productName":     domain.FieldSourceMaster,
+				"customerBalance": domain.FieldSourceMaster,
+			},
+		},
+		{
+			name:           "Uploaded mobile wins when configured",
+			precedence:     map[string]string{"mobile": domain.FieldSourceImport},
+			expectedMobile: "0922000000",
+			expectedSources: domain.FieldSources{
+				"mobile":          domain.FieldSourceImport,
+				"branchName":      domain.FieldSourceMaster,
+				"branchCode":      domain.FieldSourceMaster,
+				"productName":     domain.FieldSourceMaster,
+				"customerBalance": domain.FieldSourceMaster,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewCustomerRepository(t)
+			uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(tt.precedence), nil, nil)
+			mockRepo.On("FindByNameAndAccountNo", ctx, "John Doe", "12345").Return(master, nil).Once()
+			mockRepo.On("CheckDuplicateInValidCustomers", ctx, "John Doe", "12345").Return(nil, nil).Once()
+			mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Customer")).Return(&domain.Customer{}, nil).Once()
+
+			customers, _, err := uc.ImportCustomers(ctx, bytes.NewReader([]byte(input)), false)
+
+			assert.NoError(t, err)
+			assert.Len(t, customers, 1)
+			assert.Equal(t, tt.expectedMobile, customers[0].Mobile)
+			assert.Equal(t, "Bole", customers[0].BranchName)
+			assert.Equal(t, "BR01", customers[0].BranchCode)
+			assert.Equal(t, "Savings", customers[0].ProductName)
+			assert.Equal(t, 2500.0, customers[0].CustomerBalance)
+			assert.Equal(t, tt.expectedSources, customers[0].FieldSources)
+		})
+	}
+}
+
 func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := mocks.NewCustomerRepository(t)
-	uc := NewCustomerUseCase(mockRepo)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
 
 	tests := []struct {
 		name                 string
 		inputJSON            string
 		allowOverdraft       bool
+		dryRun               bool
 		mockSetup            func()
 		expectedTransactions []*domain.Transaction
-		expectedLogs         []map[string]interface{}
+		expectedLogs         []*domain.ValidationResult
 		expectedErr          error
 	}{
 		{
@@ -220,11 +442,10 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 			expectedTransactions: []*domain.Transaction{
 				{TransactionID: "TXN-12345678", FromAccount: "12345", ToAccount: "67890", Amount: 100.0},
 			},
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index": 1,
-					"verified":     true,
-					"transaction":  mock.Anything,
+					RecordIndex: 1,
+					Verified:    true,
 				},
 			},
 			expectedErr: nil,
@@ -257,14 +478,14 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 					Return(true, nil).Once()
 			},
 			expectedTransactions: nil,
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index":           1,
-					"verified":               false,
-					"errors":                 []string{"insufficient balance for fromAccount"},
-					"attempted_from_account": "12345",
-					"attempted_to_account":   "67890",
-					"attempted_amount":       2000.0,
+					RecordIndex:          1,
+					Verified:             false,
+					Errors:               []domain.FieldError{{Code: domain.CodeInsufficientBalance}},
+					AttemptedFromAccount: "12345",
+					AttemptedToAccount:   "67890",
+					AttemptedAmount:      2000.0,
 				},
 			},
 			expectedErr: errors.New("no valid transactions imported; see logs for details"),
@@ -288,12 +509,50 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 			expectedTransactions: []*domain.Transaction{
 				{TransactionID: "TXN-12345678", FromAccount: "12345", ToAccount: "SYNTHETIC-12345", Amount: 100.0},
 			},
-			expectedLogs: []map[string]interface{}{
+			expectedLogs: []*domain.ValidationResult{
 				{
-					"record_index": 0,
-					"verified":     true,
-					"transaction":  mock.Anything,
-					"synthetic":    true,
+					RecordIndex: 0,
+					Verified:    true,
+					Synthetic:   true,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Dry run projects balances without saving",
+			inputJSON: `[
+				{"fromAccount": "12345", "toAccount": "67890", "amount": 100.0, "date": "2025-01-01"},
+				{"fromAccount": "12345", "toAccount": "67890", "amount": 100.0, "date": "2025-01-02"}
+			]`,
+			allowOverdraft: false,
+			dryRun:         true,
+			mockSetup: func() {
+				mockRepo.On("CheckDuplicateInValidCustomers", ctx, "", "12345").
+					Return(&domain.Customer{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345", CustomerBalance: 150.0}, nil).Once()
+				mockRepo.On("CheckDuplicateInValidCustomers", ctx, "", "67890").
+					Return(&domain.Customer{ID: 2, CustomerId: "CUST-87654321", AccountNo: "67890"}, nil).Once()
+				mockRepo.On("FindAll", ctx).
+					Return([]*domain.Customer{
+						{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345", CustomerBalance: 150.0},
+					}, nil).Once()
+				mockRepo.On("HasTransactions", ctx, "12345").
+					Return(false, nil).Once()
+			},
+			expectedTransactions: []*domain.Transaction{
+				{FromAccount: "12345", ToAccount: "67890", Amount: 100.0},
+			},
+			expectedLogs: []*domain.ValidationResult{
+				{
+					RecordIndex: 1,
+					Verified:    true,
+				},
+				{
+					RecordIndex:          2,
+					Verified:             false,
+					Errors:               []domain.FieldError{{Code: domain.CodeInsufficientBalance}},
+					AttemptedFromAccount: "12345",
+					AttemptedToAccount:   "67890",
+					AttemptedAmount:      100.0,
 				},
 			},
 			expectedErr: nil,
@@ -304,7 +563,7 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 			reader := bytes.NewReader([]byte(tt.inputJSON))
-			transactions, logs, err := uc.ImportTransactions(ctx, reader, tt.allowOverdraft)
+			transactions, logs, err := uc.ImportTransactions(ctx, reader, tt.allowOverdraft, tt.dryRun)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -328,24 +587,17 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 			if len(tt.expectedLogs) > 0 {
 				assert.Len(t, logs, len(tt.expectedLogs))
 				for i, expectedLog := range tt.expectedLogs {
-					assert.Equal(t, expectedLog["record_index"], logs[i]["record_index"])
-					assert.Equal(t, expectedLog["verified"], logs[i]["verified"])
-					if !expectedLog["verified"].(bool) {
-						assert.NotEmpty(t, logs[i]["errors"])
-						if expectedLog["attempted_from_account"] != nil {
-							assert.Equal(t, expectedLog["attempted_from_account"], logs[i]["attempted_from_account"])
-						}
-						if expectedLog["attempted_to_account"] != nil {
-							assert.Equal(t, expectedLog["attempted_to_account"], logs[i]["attempted_to_account"])
-						}
-						if expectedLog["attempted_amount"] != nil {
-							assert.Equal(t, expectedLog["attempted_amount"], logs[i]["attempted_amount"])
-						}
+					assert.Equal(t, expectedLog.RecordIndex, logs[i].RecordIndex)
+					assert.Equal(t, expectedLog.Verified, logs[i].Verified)
+					assert.Equal(t, expectedLog.Synthetic, logs[i].Synthetic)
+					if !expectedLog.Verified {
+						assert.Equal(t, errorCodes(expectedLog.Errors), errorCodes(logs[i].Errors))
+						assert.Equal(t, expectedLog.AttemptedFromAccount, logs[i].AttemptedFromAccount)
+						assert.Equal(t, expectedLog.AttemptedToAccount, logs[i].AttemptedToAccount)
+						assert.Equal(t, expectedLog.AttemptedAmount, logs[i].AttemptedAmount)
 					} else {
-						assert.NotNil(t, logs[i]["transaction"])
-						if expectedLog["synthetic"] != nil {
-							assert.Equal(t, expectedLog["synthetic"], logs[i]["synthetic"])
-						}
+						assert.Empty(t, logs[i].Errors)
+						assert.NotNil(t, logs[i].Transaction)
 					}
 				}
 			} else {
@@ -358,14 +610,18 @@ func TestCustomerUseCase_ImportTransactions(t *testing.T) {
 func TestCustomerUseCase_CalculateCustomerRating(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := mocks.NewCustomerRepository(t)
-	uc := NewCustomerUseCase(mockRepo)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
 
 	tests := []struct {
-		name           string
-		customerID     string
-		mockSetup      func()
-		expectedRating float64
-		expectedErr    error
+		name            string
+		customerID      string
+		mockSetup       func()
+		expectedRating  float64
+		expectedVersion string
+		expectedReasons []string
+		expectedErr     error
 	}{
 		{
 			name:       "Customer with transactions",
@@ -395,9 +651,15 @@ func TestCustomerUseCase_CalculateCustomerRating(t *testing.T) {
 				}
 				mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
 				mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+				mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Once()
+				mockRatings.On("SaveSnapshot", ctx, mock.MatchedBy(func(s *domain.RatingSnapshot) bool {
+					return s.CustomerID == "1" && s.Rating == 3.7 && s.ScorecardVersion == "default-v2" && len(s.Factors) == 5
+				})).Return(&domain.RatingSnapshot{ID: 1}, nil).Once()
 			},
-			expectedRating: 4.9, 
-			expectedErr:    nil,
+			expectedRating:  3.7,
+			expectedVersion: "default-v2",
+			expectedReasons: []string{domain.ReasonIrregularSalary, domain.ReasonLowVolume},
+			expectedErr:     nil,
 		},
 		{
 			name:       "Customer with no transactions",
@@ -406,9 +668,13 @@ func TestCustomerUseCase_CalculateCustomerRating(t *testing.T) {
 				customer := &domain.Customer{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345"}
 				mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
 				mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return([]*domain.Transaction{}, nil).Once()
+				mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Once()
+				mockRatings.On("SaveSnapshot", ctx, mock.AnythingOfType("*domain.RatingSnapshot")).Return(&domain.RatingSnapshot{ID: 2}, nil).Once()
 			},
-			expectedRating: 1.0,
-			expectedErr:    nil,
+			expectedRating:  1.0,
+			expectedVersion: "default-v2",
+			expectedReasons: []string{domain.ReasonNoTransactions},
+			expectedErr:     nil,
 		},
 		{
 			name:       "Customer not found",
@@ -419,29 +685,466 @@ func TestCustomerUseCase_CalculateCustomerRating(t *testing.T) {
 			expectedRating: 0,
 			expectedErr:    errors.New("failed to find customer: not found"),
 		},
+		{
+			name:       "Product scorecard is used and recorded",
+			customerID: "1",
+			mockSetup: func() {
+				customer := &domain.Customer{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345", ProductName: "Payroll"}
+				transactions := []*domain.Transaction{
+					{TransactionID: "TXN-1", FromAccount: "12345", ToAccount: "67890", Amount: 500.0, Date: time.Now()},
+				}
+				mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
+				mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+				mockScorecards.On("FindActive", ctx, "Payroll").Return(&domain.Scorecard{
+					Version:   "payroll-v2",
+					Factors:   domain.ScorecardFactors{{Name: domain.FactorCount, Weight: 1, Cap: 1}},
+					Scale:     10,
+					MinRating: 1,
+					MaxRating: 10,
+				}, nil).Once()
+				mockRatings.On("SaveSnapshot", ctx, mock.MatchedBy(func(s *domain.RatingSnapshot) bool {
+					return s.ScorecardVersion == "payroll-v2"
+				})).Return(&domain.RatingSnapshot{ID: 3}, nil).Once()
+			},
+			expectedRating:  10.0,
+			expectedVersion: "payroll-v2",
+			expectedReasons: []string{},
+			expectedErr:     nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			rating, err := uc.CalculateCustomerRating(ctx, tt.customerID)
+			rating, err := uc.CalculateCustomerRating(ctx, tt.customerID, time.Time{})
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr.Error())
-				assert.Equal(t, 0.0, rating)
+				assert.Nil(t, rating)
 			} else {
 				assert.NoError(t, err)
-				assert.InDelta(t, tt.expectedRating, rating, 0.1)
+				assert.InDelta(t, tt.expectedRating, rating.Rating, 0.1)
+				assert.Equal(t, tt.expectedVersion, rating.ScorecardVersion)
+				reasons := []string{}
+				for _, reason := range rating.Reasons {
+					reasons = append(reasons, reason.Code)
+				}
+				assert.Equal(t, tt.expectedReasons, reasons)
+			}
+		})
+	}
+}
+
+func TestCustomerUseCase_CalculateCustomerRatingBreakdown(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	customer := &domain.Customer{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345", CustomerBalance: 1000.0}
+	transactions := []*domain.Transaction{
+		{TransactionID: "TXN-1", FromAccount: "12345", ToAccount: "67890", Amount: 500.0, Date: time.Now().AddDate(0, 0, -365)},
+		{TransactionID: "TXN-2", FromAccount: "12345", ToAccount: "67890", Amount: 500.0, Date: time.Now()},
+	}
+	mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+	mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Once()
+	mockRatings.On("SaveSnapshot", ctx, mock.AnythingOfType("*domain.RatingSnapshot")).Return(&domain.RatingSnapshot{ID: 1}, nil).Once()
+
+	rating, err := uc.CalculateCustomerRating(ctx, "1", time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FactorBreakdown{
+		{Name: domain.FactorCount, RawValue: 2, NormalizedScore: 0.2, Weight: 0.25, Contribution: 0.5},
+		{Name: domain.FactorVolume, RawValue: 1000, NormalizedScore: 0.1, Weight: 0.25, Contribution: 0.25},
+		{Name: domain.FactorDuration, RawValue: 365, NormalizedScore: 1, Weight: 0.15, Contribution: 1.5},
+		{Name: domain.FactorStability, RawValue: 26.1, NormalizedScore: 0.997, Weight: 0.15, Contribution: 1.5},
+		{Name: domain.FactorSalary, RawValue: 0, NormalizedScore: 0, Weight: 0.2, Contribution: 0},
+	}, rating.Factors)
+	assert.Equal(t, domain.FactorSalary, rating.Reasons[0].Factor)
+	assert.NotEmpty(t, rating.Reasons[0].Message)
+}
+
+func TestCustomerUseCase_CalculateCustomerRatingAsOf(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	asOf := now.AddDate(0, 0, -30)
+	customer := &domain.Customer{ID: 1, CustomerId: "CUST-12345678", AccountNo: "12345", CustomerBalance: 1000.0}
+	transactions := []*domain.Transaction{
+		{TransactionID: "TXN-1", FromAccount: "12345", ToAccount: "67890", Amount: 500.0, Date: now.AddDate(0, 0, -365)},
+		{TransactionID: "TXN-2", FromAccount: "67890", ToAccount: "12345", Amount: 300.0, Date: now.AddDate(0, 0, -60)},
+		{TransactionID: "TXN-3", FromAccount: "12345", ToAccount: "67890", Amount: 500.0, Date: now.AddDate(0, 0, -10)},
+		{TransactionID: "TXN-4", FromAccount: "67890", ToAccount: "12345", Amount: 2000.0, Date: now},
+	}
+	mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+	mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Once()
+	mockRatings.On("SaveSnapshot", ctx, mock.MatchedBy(func(s *domain.RatingSnapshot) bool {
+		return s.AsOf.Equal(asOf)
+	})).Return(&domain.RatingSnapshot{ID: 1}, nil).Once()
+
+	rating, err := uc.CalculateCustomerRating(ctx, "1", asOf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asOf, rating.AsOf)
+	raw := map[string]float64{}
+	for _, factor := range rating.Factors {
+		raw[factor.Name] = factor.RawValue
+	}
+	assert.Equal(t, 2.0, raw[domain.FactorCount])
+	assert.Equal(t, 500.0, raw[domain.FactorVolume])
+	assert.Equal(t, 305.0, raw[domain.FactorDuration])
+	// The balance as of the date is 1000 - 2000 + 500 = -500. It was -800 for
+	// the 305 days after TXN-1 and -500 from TXN-2 on.
+	assert.Equal(t, 17.12, raw[domain.FactorStability])
+	assert.Equal(t, float64(1000), customer.CustomerBalance)
+}
+
+func TestCustomerUseCase_RateAllCustomers(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	customers := []*domain.Customer{
+		{ID: 1, AccountNo: "11111", CustomerBalance: 1000.0},
+		{ID: 2, AccountNo: "22222"},
+		{ID: 3, AccountNo: "33333"},
+	}
+	mockRepo.On("FindAll", ctx).Return(customers, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "11111").Return([]*domain.Transaction{
+		{TransactionID: "TXN-1", FromAccount: "11111", ToAccount: "67890", Amount: 500.0, Date: now.AddDate(0, 0, -365)},
+		{TransactionID: "TXN-2", FromAccount: "11111", ToAccount: "67890", Amount: 500.0, Date: now},
+	}, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "22222").Return([]*domain.Transaction{}, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "33333").Return(nil, config.ErrInternalServer).Once()
+	mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Times(2)
+	mockRatings.On("SaveSnapshot", ctx, mock.AnythingOfType("*domain.RatingSnapshot")).Return(&domain.RatingSnapshot{}, nil).Times(2)
+
+	summary, err := uc.RateAllCustomers(ctx, now, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, summary.Total)
+	assert.Equal(t, 2, summary.Rated)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, "3", summary.Failures[0].CustomerID)
+	assert.Equal(t, map[string]int{"1-2": 1, "3-4": 1}, summary.Distribution)
+	assert.Equal(t, map[string]int{"default-v2": 2}, summary.ByScorecard)
+	assert.Equal(t, 1.0, summary.Min)
+	assert.Equal(t, 3.7, summary.Max)
+	assert.Equal(t, 2.35, summary.Mean)
+	assert.Equal(t, 2.35, summary.Median)
+}
+
+func TestNewBalanceHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC) }
+	created := func(h int) time.Time { return time.Date(2025, time.March, 1, h, 0, 0, 0, time.UTC) }
+	transactions := []*domain.Transaction{
+		{TransactionID: "TXN-3", FromAccount: "12345", ToAccount: "67890", Amount: 200, Date: day(3), CreatedAt: created(9)},
+		{TransactionID: "TXN-2", FromAccount: "67890", ToAccount: "12345", Amount: 1000, Date: day(3), CreatedAt: created(8)},
+		{TransactionID: "TXN-1", FromAccount: "12345", ToAccount: "67890", Amount: 300, Date: day(1)},
+		{TransactionID: "TXN-SELF", FromAccount: "12345", ToAccount: "12345", Amount: 5000, Date: day(2)},
+		{TransactionID: "TXN-OTHER", FromAccount: "67890", ToAccount: "55555", Amount: 700, Date: day(2)},
+		{TransactionID: "TXN-4", FromAccount: "12345", ToAccount: domain.SyntheticAccountPrefix + "12345", Amount: 100, Date: day(5)},
+	}
+
+	history := domain.NewBalanceHistory("12345", 1400, transactions)
+
+	assert.Equal(t, 1000.0, history.OpeningBalance)
+	assert.Equal(t, []domain.BalanceEntry{
+		{TransactionID: "TXN-1", Date: day(1), Amount: -300, Balance: 700},
+		{TransactionID: "TXN-2", Date: day(3), Amount: 1000, Balance: 1700},
+		{TransactionID: "TXN-3", Date: day(3), Amount: -200, Balance: 1500},
+		{TransactionID: "TXN-4", Date: day(5), Amount: -100, Balance: 1400, Synthetic: true},
+	}, history.Entries)
+	assert.Equal(t, 1000.0, history.BalanceAt(day(1).Add(-time.Hour)))
+	assert.Equal(t, 700.0, history.BalanceAt(day(2)))
+	assert.Equal(t, 1500.0, history.BalanceAt(day(4)))
+	assert.Equal(t, []domain.DailyBalance{
+		{Date: day(1), Balance: 700},
+		{Date: day(2), Balance: 700},
+		{Date: day(3), Balance: 1500},
+		{Date: day(4), Balance: 1500},
+		{Date: day(5), Balance: 1400},
+	}, history.Daily())
+}
+
+func TestDetectSalary(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC) }
+	credit := func(from domain.AccountNo, amount float64, date time.Time) *domain.Transaction {
+		return &domain.Transaction{FromAccount: from, ToAccount: "12345", Amount: amount, Date: date}
+	}
+
+	tests := []struct {
+		name         string
+		transactions []*domain.Transaction
+		expected     salaryEstimate
+	}{
+		{
+			name: "Monthly employer credits are detected",
+			transactions: []*domain.Transaction{
+				credit("99999", 10000, day(time.April, 25)),
+				credit("99999", 9800, day(time.January, 24)),
+				credit("55555", 300, day(time.February, 3)),
+				credit("99999", 10200, day(time.February, 25)),
+				credit("55555", 5000, day(time.March, 9)),
+				credit("99999", 10000, day(time.March, 25)),
+				{FromAccount: "12345", ToAccount: "67890", Amount: 2000, Date: day(time.March, 28)},
+			},
+			expected: salaryEstimate{SourceAccount: "99999", MonthlyAmount: 10000, Credits: 4},
+		},
+		{
+			name: "Self-transfers are not salary",
+			transactions: []*domain.Transaction{
+				{FromAccount: "12345", ToAccount: "12345", Amount: 5000, Date: day(time.January, 25)},
+				{FromAccount: "12345", ToAccount: "12345", Amount: 5000, Date: day(time.February, 25)},
+				{FromAccount: "12345", ToAccount: "12345", Amount: 5000, Date: day(time.March, 25)},
+			},
+			expected: salaryEstimate{},
+		},
+		{
+			name: "Irregular cadence is not salary",
+			transactions: []*domain.Transaction{
+				credit("99999", 10000, day(time.January, 5)),
+				credit("99999", 10000, day(time.January, 15)),
+				credit("99999", 10000, day(time.March, 20)),
+			},
+			expected: salaryEstimate{},
+		},
+		{
+			name: "Dissimilar amounts are left out of the series",
+			transactions: []*domain.Transaction{
+				credit("99999", 8000, day(time.January, 28)),
+				credit("99999", 8000, day(time.February, 27)),
+				credit("99999", 25000, day(time.March, 10)),
+				credit("99999", 8000, day(time.March, 28)),
+			},
+			expected: salaryEstimate{SourceAccount: "99999", MonthlyAmount: 8000, Credits: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectSalary("12345", tt.transactions))
+		})
+	}
+}
+
+func TestCustomerUseCase_CalculateCustomerRatingSalary(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	payday := time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC)
+	var transactions []*domain.Transaction
+	for month := 0; month < 3; month++ {
+		transactions = append(transactions, &domain.Transaction{
+			TransactionID: fmt.Sprintf("SAL-%d", month),
+			FromAccount:   "99999",
+			ToAccount:     "12345",
+			Amount:        10000,
+			Date:          payday.AddDate(0, month, 0),
+		})
+	}
+	mockRepo.On("FindByID", ctx, "1").Return(&domain.Customer{ID: 1, AccountNo: "12345", ProductName: "Payroll"}, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+	mockScorecards.On("FindActive", ctx, "Payroll").Return(&domain.Scorecard{
+		Version: "payroll-salary",
+		Factors: domain.ScorecardFactors{
+			{Name: domain.FactorSalary, Weight: 0.5, Cap: 6},
+			{Name: domain.FactorCount, Weight: 0.5, Cap: 3},
+		},
+		Scale:      10,
+		MinRating:  1,
+		MaxRating:  10,
+		LimitRatio: 0.4,
+	}, nil).Once()
+	mockRatings.On("SaveSnapshot", ctx, mock.MatchedBy(func(s *domain.RatingSnapshot) bool {
+		return s.EstimatedMonthlySalary == 10000 && s.EligibleLimit == 3000
+	})).Return(&domain.RatingSnapshot{ID: 1}, nil).Once()
+
+	rating, err := uc.CalculateCustomerRating(ctx, "1", payday.AddDate(0, 3, 0))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7.5, rating.Rating)
+	assert.Equal(t, 10000.0, rating.EstimatedMonthlySalary)
+	assert.Equal(t, 3000.0, rating.EligibleLimit)
+	assert.Equal(t, domain.FactorBreakdown{Name: domain.FactorSalary, RawValue: 3, NormalizedScore: 0.5, Weight: 0.5, Contribution: 2.5}, rating.Factors[0])
+	assert.Equal(t, domain.ReasonIrregularSalary, rating.Reasons[0].Code)
+}
+
+func TestCustomerUseCase_SimulateCustomerRating(t *testing.T) {
+	ctx := context.Background()
+	payday := time.Now().AddDate(0, -2, 0).Truncate(24 * time.Hour)
+	customer := &domain.Customer{ID: 1, AccountNo: "12345", CustomerBalance: 20000}
+	transactions := []*domain.Transaction{
+		{TransactionID: "SAL-1", FromAccount: "99999", ToAccount: "12345", Amount: 10000, Date: payday},
+		{TransactionID: "SAL-2", FromAccount: "99999", ToAccount: "12345", Amount: 10000, Date: payday.AddDate(0, 1, 0)},
+	}
+	salaryScorecard := &domain.Scorecard{
+		Version:    "salary-only",
+		Factors:    domain.ScorecardFactors{{Name: domain.FactorSalary, Weight: 1, Cap: 4}},
+		Scale:      10,
+		MinRating:  1,
+		MaxRating:  10,
+		LimitRatio: 0.5,
+	}
+
+	tests := []struct {
+		name           string
+		req            *domain.RatingSimulationRequest
+		expectedRating float64
+		expectedErr    error
+	}{
+		{
+			name: "Next salary credit is merged with the real history",
+			req: &domain.RatingSimulationRequest{Transactions: []domain.SimulatedTransaction{
+				{Direction: "credit", Amount: 10000, Date: payday.AddDate(0, 2, 0).Format("2006-01-02"), Counterparty: "99999"},
+			}},
+			expectedRating: 7.5,
+		},
+		{
+			name: "Invalid date is rejected",
+			req: &domain.RatingSimulationRequest{Transactions: []domain.SimulatedTransaction{
+				{Direction: "credit", Amount: 10000, Date: "next week"},
+			}},
+			expectedErr: config.ErrInvalidTransactionPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewCustomerRepository(t)
+			mockScorecards := mocks.NewScorecardRepository(t)
+			uc := NewCustomerUseCase(mockRepo, mockScorecards, mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+			mockRepo.On("FindByID", ctx, "1").Return(customer, nil).Once()
+			mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return(transactions, nil).Once()
+			if tt.expectedErr == nil {
+				mockScorecards.On("FindActive", ctx, "").Return(salaryScorecard, nil).Once()
 			}
+
+			rating, err := uc.SimulateCustomerRating(ctx, "1", tt.req)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, rating.Simulated)
+			assert.Equal(t, tt.expectedRating, rating.Rating)
+			assert.Equal(t, 10000.0, rating.EstimatedMonthlySalary)
+			assert.Len(t, transactions, 2)
+			assert.Equal(t, 20000.0, customer.CustomerBalance)
 		})
 	}
 }
 
+func TestRulesStrategy_Rate(t *testing.T) {
+	payday := time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC)
+	var transactions []*domain.Transaction
+	for month := 0; month < 3; month++ {
+		transactions = append(transactions, &domain.Transaction{
+			TransactionID: fmt.Sprintf("SAL-%d", month),
+			FromAccount:   "99999",
+			ToAccount:     "12345",
+			Amount:        10000,
+			Date:          payday.AddDate(0, month, 0),
+		})
+	}
+	input := &domain.RatingInput{
+		CustomerID:   "1",
+		Customer:     &domain.Customer{AccountNo: "12345", CustomerBalance: 30000},
+		Transactions: transactions,
+		Scorecard:    domain.DefaultScorecard(),
+		AsOf:         payday.AddDate(0, 3, 0),
+	}
+
+	result := NewRulesStrategy(nil).Rate(input)
+
+	// Only the salary rule is met: the minimum rating plus its 3 points.
+	assert.Equal(t, domain.StrategyRules, result.Strategy)
+	assert.Equal(t, 4.0, result.Rating)
+	assert.Equal(t, 10000.0, result.EstimatedMonthlySalary)
+	assert.Len(t, result.Factors, 5)
+	assert.Equal(t, []string{domain.ReasonLowCount, domain.ReasonShortHistory}, []string{result.Reasons[0].Code, result.Reasons[1].Code})
+}
+
+func TestCustomerUseCase_CalculateCustomerRatingShadow(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockScorecards := mocks.NewScorecardRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mockScorecards, mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil),
+		NewScorecardStrategy(), NewRulesStrategy(nil))
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	mockRepo.On("FindByID", ctx, "1").Return(&domain.Customer{ID: 1, AccountNo: "12345"}, nil).Once()
+	mockRepo.On("GetTransactionsByAccount", ctx, "12345").Return([]*domain.Transaction{}, nil).Once()
+	mockScorecards.On("FindActive", ctx, "").Return(nil, nil).Once()
+	mockRatings.On("SaveSnapshot", ctx, mock.MatchedBy(func(s *domain.RatingSnapshot) bool {
+		return s.Strategy == domain.StrategyScorecard
+	})).Return(&domain.RatingSnapshot{ID: 1}, nil).Once()
+
+	rating, err := uc.CalculateCustomerRating(ctx, "1", time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StrategyScorecard, rating.Strategy)
+	assert.Contains(t, logs.String(), "rating shadow: customer=1")
+	assert.Contains(t, logs.String(), "scorecard=1.0 rules=1.0 diff=0.0")
+}
+
+func TestNewRatingStrategy(t *testing.T) {
+	strategy, err := NewRatingStrategy(domain.StrategyRules)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StrategyRules, strategy.Name())
+
+	strategy, err = NewRatingStrategy("")
+	assert.NoError(t, err)
+	assert.Nil(t, strategy)
+
+	_, err = NewRatingStrategy("neural")
+	assert.Error(t, err)
+}
+
+func TestCustomerUseCase_RatingHistory(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	mockRatings := mocks.NewRatingRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mockRatings, services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	snapshots := []*domain.RatingSnapshot{
+		{ID: 1, CustomerID: "1", Rating: 4.2, ScorecardVersion: "default-v1", AsOf: time.Now().AddDate(0, -1, 0)},
+		{ID: 2, CustomerID: "1", Rating: 4.9, ScorecardVersion: "default-v1", AsOf: time.Now()},
+	}
+	mockRepo.On("FindByID", ctx, "1").Return(&domain.Customer{ID: 1}, nil).Once()
+	mockRatings.On("ListSnapshots", ctx, "1").Return(snapshots, nil).Once()
+
+	history, err := uc.RatingHistory(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, snapshots, history)
+
+	mockRepo.On("FindByID", ctx, "2").Return(nil, config.ErrNotFound).Once()
+	_, err = uc.RatingHistory(ctx, "2")
+	assert.Equal(t, config.ErrNotFound, err)
+}
+
 func TestCustomerUseCase_GetCustomer(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := mocks.NewCustomerRepository(t)
-	uc := NewCustomerUseCase(mockRepo)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
 
 	tests := []struct {
 		name             string
@@ -491,7 +1194,7 @@ func TestCustomerUseCase_GetCustomer(t *testing.T) {
 func TestCustomerUseCase_GetAllCustomers(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := mocks.NewCustomerRepository(t)
-	uc := NewCustomerUseCase(mockRepo)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
 
 	tests := []struct {
 		name              string
@@ -548,3 +1251,73 @@ func TestCustomerUseCase_GetAllCustomers(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomerUseCase_ResolveReview(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewCustomerRepository(t)
+	uc := NewCustomerUseCase(mockRepo, mocks.NewScorecardRepository(t), mocks.NewRatingRepository(t), services.NewNameMatcher(0.9, 0.75), services.NewCustomerEnricher(nil), nil, nil)
+
+	pendingReview := func() *domain.CustomerReview {
+		return &domain.CustomerReview{ID: 7, SubmittedName: "Abebe Kebe", AccountNo: "12345", MatchedCustomerID: 1, MatchedName: "Abebe Kebede", Status: domain.ReviewStatusPending}
+	}
+
+	t.Run("Approve promotes the master record", func(t *testing.T) {
+		mockRepo.On("FindReviewByID", ctx, uint(7)).Return(pendingReview(), nil).Once()
+		mockRepo.On("CheckDuplicateInValidCustomers", ctx, "Abebe Kebede", "12345").Return(nil, nil).Once()
+		mockRepo.On("FindByAccountNo", ctx, "12345").
+			Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345", BranchCode: "BR01"}, nil).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Customer")).Return(&domain.Customer{}, nil).Once()
+		mockRepo.On("UpdateReview", ctx, mock.MatchedBy(func(r *domain.CustomerReview) bool {
+			return r.ID == 7 && r.Status == domain.ReviewStatusApproved && r.ReviewedBy == 1 && r.ReviewedAt != nil
+		})).Return(&domain.CustomerReview{}, nil).Once()
+		mockRepo.On("FindPendingReviews", ctx, 1).Return([]*domain.CustomerReview{
+			{ID: 8, SubmittedName: "A. Kebede", AccountNo: "012345", MatchedCustomerID: 1, Status: domain.ReviewStatusPending},
+		}, nil).Once()
+		mockRepo.On("UpdateReview", ctx, mock.MatchedBy(func(r *domain.CustomerReview) bool {
+			return r.ID == 8 && r.Status == domain.ReviewStatusApproved && r.ReviewedBy == 1 && r.Note == "resolved with review 7"
+		})).Return(&domain.CustomerReview{}, nil).Once()
+
+		customer, err := uc.ApproveReview(ctx, 7, 1, "same person")
+		assert.NoError(t, err)
+		assert.Equal(t, "Abebe Kebede", customer.CustomerName)
+		assert.Equal(t, domain.AccountNo("12345"), customer.AccountNo)
+		assert.Equal(t, "BR01", customer.BranchCode)
+	})
+
+	t.Run("Approve rejects duplicates", func(t *testing.T) {
+		mockRepo.On("FindReviewByID", ctx, uint(7)).Return(pendingReview(), nil).Once()
+		mockRepo.On("CheckDuplicateInValidCustomers", ctx, "Abebe Kebede", "12345").Return(&domain.Customer{ID: 3}, nil).Once()
+
+		customer, err := uc.ApproveReview(ctx, 7, 1, "")
+		assert.Equal(t, config.ErrCustomerAlreadyExists, err)
+		assert.Nil(t, customer)
+	})
+
+	t.Run("Reject marks the review", func(t *testing.T) {
+		mockRepo.On("FindReviewByID", ctx, uint(7)).Return(pendingReview(), nil).Once()
+		mockRepo.On("UpdateReview", ctx, mock.MatchedBy(func(r *domain.CustomerReview) bool {
+			return r.Status == domain.ReviewStatusRejected && r.Note == "different person"
+		})).Return(&domain.CustomerReview{Status: domain.ReviewStatusRejected}, nil).Once()
+
+		review, err := uc.RejectReview(ctx, 7, 1, "different person")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReviewStatusRejected, review.Status)
+	})
+
+	t.Run("Resolved reviews cannot be decided again", func(t *testing.T) {
+		resolved := pendingReview()
+		resolved.Status = domain.ReviewStatusApproved
+		mockRepo.On("FindReviewByID", ctx, uint(7)).Return(resolved, nil).Once()
+
+		_, err := uc.RejectReview(ctx, 7, 1, "")
+		assert.Equal(t, config.ErrReviewAlreadyResolved, err)
+	})
+}
+
+func errorCodes(errs []domain.FieldError) []domain.ValidationErrorCode {
+	codes := []domain.ValidationErrorCode{}
+	for _, e := range errs {
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
@@ -20,47 +20,156 @@ func NewInviteUseCase(inviteRepo domain.InviteRepository, userRepo domain.UserRe
 	return &InviteUseCaseImpl{inviteRepo: inviteRepo, userRepo: userRepo, emailService: emailService, jwtService: jwtService}
 }
 
-func (u *InviteUseCaseImpl) SendInvite(ctx context.Context, adminID uint, email string) (string, error) {
+// inviteExpiry is how long an invite link stays usable after it is sent.
+const inviteExpiry = 24 * time.Hour
+
+// SendInvite invites email to register with role; an empty role invites an
+// uploader.
+func (u *InviteUseCaseImpl) SendInvite(ctx context.Context, adminID uint, email, role string) (string, error) {
+	if role == "" {
+		role = domain.RoleUploader
+	}
+	if !domain.IsValidRole(role) {
+		return "", config.ErrInvalidRole
+	}
 	if _, err := u.userRepo.FindByEmail(ctx, email); err == nil {
 		return "", config.ErrBadRequest
 	}
-	inviteToken, err := u.jwtService.GenerateInviteToken(email, adminID)
+	inviteToken, err := u.jwtService.GenerateInviteToken(email, role, adminID)
 	if err != nil {
 		return "", config.ErrInternalServer
 	}
 	invite := &domain.Invite{
 		Token:     inviteToken,
 		Email:     email,
-		Expiry:    time.Now().Add(24 * time.Hour),
+		Role:      role,
+		Expiry:    time.Now().Add(inviteExpiry),
 		InvitedBy: adminID,
 	}
 	_, err = u.inviteRepo.Create(ctx, invite)
 	if err != nil {
 		return "", config.ErrInternalServer
 	}
-	link := fmt.Sprintf("http://localhost:8080/register?token=%s", inviteToken)
-	if err := u.emailService.SendInvite(email, link); err != nil {
-		fmt.Println("Email send error:", err)
-	}
-	return link, nil
+	return u.sendLink(invite), nil
 }
 
+// ValidateInvite returns the email a pending invite was sent to, so the
+// registration form can show it. It does not use up the invite.
 func (u *InviteUseCaseImpl) ValidateInvite(ctx context.Context, token string) (string, error) {
-	invite, err := u.inviteRepo.FindByToken(ctx, token)
+	invite, err := pendingInvite(ctx, u.inviteRepo, u.jwtService, token)
 	if err != nil {
-		return "", config.ErrNotFound
+		return "", err
 	}
-	if invite.Used || invite.Expiry.Before(time.Now()) {
-		return "", config.ErrBadRequest
+	return invite.Email, nil
+}
+
+// ListInvites returns the invites in status, or all of them when status is
+// empty, each with its current status.
+func (u *InviteUseCaseImpl) ListInvites(ctx context.Context, status string) ([]*domain.Invite, error) {
+	if status != "" && !domain.IsValidInviteStatus(status) {
+		return nil, config.ErrBadRequest
 	}
-	_, err = u.jwtService.ValidateToken(token)
+	invites, err := u.inviteRepo.List(ctx, status)
 	if err != nil {
-		return "", config.ErrUnauthorized
+		return nil, err
+	}
+	now := time.Now()
+	for _, invite := range invites {
+		invite.Status = invite.InviteStatus(now)
+	}
+	if invites == nil {
+		invites = []*domain.Invite{}
 	}
-	invite.Used = true
-	_, err = u.inviteRepo.Update(ctx, invite)
+	return invites, nil
+}
+
+// ResendInvite issues a pending or expired invite a fresh token and expiry
+// and emails the new link. The old link stops working.
+func (u *InviteUseCaseImpl) ResendInvite(ctx context.Context, adminID, id uint) (string, error) {
+	invite, err := u.findInvite(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	switch invite.InviteStatus(time.Now()) {
+	case domain.InviteStatusUsed:
+		return "", config.ErrInviteAccepted
+	case domain.InviteStatusRevoked:
+		return "", config.ErrInviteRevoked
+	}
+	inviteToken, err := u.jwtService.GenerateInviteToken(invite.Email, invite.Role, adminID)
 	if err != nil {
 		return "", config.ErrInternalServer
 	}
-	return invite.Email, nil
-}
\ No newline at end of file
+	invite.Token = inviteToken
+	invite.Expiry = time.Now().Add(inviteExpiry)
+	if _, err := u.inviteRepo.Update(ctx, invite); err != nil {
+		return "", config.ErrInternalServer
+	}
+	return u.sendLink(invite), nil
+}
+
+// RevokeInvite stops an invite that has not been used from registering.
+func (u *InviteUseCaseImpl) RevokeInvite(ctx context.Context, id uint) (*domain.Invite, error) {
+	invite, err := u.findInvite(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invite.Used {
+		return nil, config.ErrInviteAccepted
+	}
+	if invite.RevokedAt == nil {
+		now := time.Now()
+		invite.RevokedAt = &now
+		if _, err := u.inviteRepo.Update(ctx, invite); err != nil {
+			return nil, config.ErrInternalServer
+		}
+	}
+	invite.Status = invite.InviteStatus(time.Now())
+	return invite, nil
+}
+
+func (u *InviteUseCaseImpl) findInvite(ctx context.Context, id uint) (*domain.Invite, error) {
+	invite, err := u.inviteRepo.FindByID(ctx, id)
+	if err == config.ErrNotFound {
+		return nil, config.ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return invite, nil
+}
+
+// sendLink emails the registration link of invite and returns it. A failed
+// email is logged; the admin can still pass the link on.
+func (u *InviteUseCaseImpl) sendLink(invite *domain.Invite) string {
+	link := fmt.Sprintf("http://localhost:8080/register?token=%s", invite.Token)
+	if err := u.emailService.SendInvite(invite.Email, link); err != nil {
+		fmt.Println("Email send error:", err)
+	}
+	return link
+}
+
+// pendingInvite loads the invite behind token and checks it can still be used
+// to register.
+func pendingInvite(ctx context.Context, inviteRepo domain.InviteRepository, jwtService domain.JWTService, token string) (*domain.Invite, error) {
+	invite, err := inviteRepo.FindByToken(ctx, token)
+	if err == config.ErrNotFound {
+		return nil, config.ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, config.ErrInternalServer
+	}
+	switch invite.InviteStatus(time.Now()) {
+	case domain.InviteStatusUsed:
+		return nil, config.ErrInviteAccepted
+	case domain.InviteStatusRevoked:
+		return nil, config.ErrInviteRevoked
+	case domain.InviteStatusExpired:
+		return nil, config.ErrInviteExpired
+	}
+	claims, err := jwtService.ValidateInviteToken(token)
+	if err != nil || claims.Email != invite.Email || claims.Role != invite.Role {
+		return nil, config.ErrUnauthorized
+	}
+	return invite, nil
+}
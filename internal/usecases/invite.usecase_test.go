@@ -23,6 +23,11 @@ func (m *MockEmailService) SendInvite(email, link string) error {
 	return args.Error(0)
 }
 
+func (m *MockEmailService) SendPasswordReset(email, link string) error {
+	args := m.Called(email, link)
+	return args.Error(0)
+}
+
 func TestInviteUseCase_SendInvite(t *testing.T) {
 	ctx := context.Background()
 	mockInviteRepo := mocks.NewInviteRepository(t)
@@ -35,6 +40,7 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 		name         string
 		adminID      uint
 		email        string
+		role         string
 		mockSetup    func()
 		expectedLink string
 		expectedErr  error
@@ -45,19 +51,42 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 			email:   "test@example.com",
 			mockSetup: func() {
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(nil, errors.New("not found")).Once()
-				mockJWTService.On("GenerateInviteToken", "test@example.com", uint(1)).Return("invite_token", nil).Once()
-				invite := &domain.Invite{
-					Token:     "invite_token",
-					Email:     "test@example.com",
-					Expiry:    time.Now().Add(24 * time.Hour),
-					InvitedBy: 1,
-				}
-				mockInviteRepo.On("Create", ctx, invite).Return(invite, nil).Once()
+				mockJWTService.On("GenerateInviteToken", "test@example.com", domain.RoleUploader, uint(1)).Return("invite_token", nil).Once()
+				invite := mock.MatchedBy(func(invite *domain.Invite) bool {
+					return invite.Token == "invite_token" && invite.Email == "test@example.com" && invite.Role == domain.RoleUploader && invite.InvitedBy == 1 &&
+						time.Until(invite.Expiry) > 23*time.Hour
+				})
+				mockInviteRepo.On("Create", ctx, invite).Return(&domain.Invite{}, nil).Once()
 				mockEmailService.On("SendInvite", "test@example.com", "http://localhost:8080/register?token=invite_token").Return(nil).Once()
 			},
 			expectedLink: "http://localhost:8080/register?token=invite_token",
 			expectedErr:  nil,
 		},
+		{
+			name:    "Role chosen by the admin",
+			adminID: 1,
+			email:   "analyst@example.com",
+			role:    domain.RoleAnalyst,
+			mockSetup: func() {
+				mockUserRepo.On("FindByEmail", ctx, "analyst@example.com").Return(nil, config.ErrNotFound).Once()
+				mockJWTService.On("GenerateInviteToken", "analyst@example.com", domain.RoleAnalyst, uint(1)).Return("analyst_token", nil).Once()
+				mockInviteRepo.On("Create", ctx, mock.MatchedBy(func(invite *domain.Invite) bool {
+					return invite.Role == domain.RoleAnalyst
+				})).Return(&domain.Invite{}, nil).Once()
+				mockEmailService.On("SendInvite", "analyst@example.com", "http://localhost:8080/register?token=analyst_token").Return(nil).Once()
+			},
+			expectedLink: "http://localhost:8080/register?token=analyst_token",
+			expectedErr:  nil,
+		},
+		{
+			name:         "Unknown role",
+			adminID:      1,
+			email:        "test@example.com",
+			role:         "superuser",
+			mockSetup:    func() {},
+			expectedLink: "",
+			expectedErr:  config.ErrInvalidRole,
+		},
 		{
 			name:    "User already exists",
 			adminID: 1,
@@ -75,7 +104,7 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 			email:   "test@example.com",
 			mockSetup: func() {
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(nil, errors.New("not found")).Once()
-				mockJWTService.On("GenerateInviteToken", "test@example.com", uint(1)).Return("", errors.New("token error")).Once()
+				mockJWTService.On("GenerateInviteToken", "test@example.com", domain.RoleUploader, uint(1)).Return("", errors.New("token error")).Once()
 			},
 			expectedLink: "",
 			expectedErr:  config.ErrInternalServer,
@@ -86,13 +115,11 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 			email:   "test@example.com",
 			mockSetup: func() {
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(nil, errors.New("not found")).Once()
-				mockJWTService.On("GenerateInviteToken", "test@example.com", uint(1)).Return("invite_token", nil).Once()
-				invite := &domain.Invite{
-					Token:     "invite_token",
-					Email:     "test@example.com",
-					Expiry:    time.Now().Add(24 * time.Hour),
-					InvitedBy: 1,
-				}
+				mockJWTService.On("GenerateInviteToken", "test@example.com", domain.RoleUploader, uint(1)).Return("invite_token", nil).Once()
+				invite := mock.MatchedBy(func(invite *domain.Invite) bool {
+					return invite.Token == "invite_token" && invite.Email == "test@example.com" && invite.Role == domain.RoleUploader && invite.InvitedBy == 1 &&
+						time.Until(invite.Expiry) > 23*time.Hour
+				})
 				mockInviteRepo.On("Create", ctx, invite).Return(nil, errors.New("create error")).Once()
 			},
 			expectedLink: "",
@@ -104,14 +131,12 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 			email:   "test@example.com",
 			mockSetup: func() {
 				mockUserRepo.On("FindByEmail", ctx, "test@example.com").Return(nil, errors.New("not found")).Once()
-				mockJWTService.On("GenerateInviteToken", "test@example.com", uint(1)).Return("invite_token", nil).Once()
-				invite := &domain.Invite{
-					Token:     "invite_token",
-					Email:     "test@example.com",
-					Expiry:    time.Now().Add(24 * time.Hour),
-					InvitedBy: 1,
-				}
-				mockInviteRepo.On("Create", ctx, invite).Return(invite, nil).Once()
+				mockJWTService.On("GenerateInviteToken", "test@example.com", domain.RoleUploader, uint(1)).Return("invite_token", nil).Once()
+				invite := mock.MatchedBy(func(invite *domain.Invite) bool {
+					return invite.Token == "invite_token" && invite.Email == "test@example.com" && invite.Role == domain.RoleUploader && invite.InvitedBy == 1 &&
+						time.Until(invite.Expiry) > 23*time.Hour
+				})
+				mockInviteRepo.On("Create", ctx, invite).Return(&domain.Invite{}, nil).Once()
 				mockEmailService.On("SendInvite", "test@example.com", "http://localhost:8080/register?token=invite_token").Return(errors.New("email error")).Once()
 			},
 			expectedLink: "http://localhost:8080/register?token=invite_token",
@@ -122,7 +147,7 @@ func TestInviteUseCase_SendInvite(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			link, err := uc.SendInvite(ctx, tt.adminID, tt.email)
+			link, err := uc.SendInvite(ctx, tt.adminID, tt.email, tt.role)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -144,6 +169,7 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 	mockJWTService := &MockJWTService{}
 	uc := NewInviteUseCase(mockInviteRepo, mockUserRepo, mockEmailService, mockJWTService)
 
+	revokedAt := time.Now()
 	tests := []struct {
 		name          string
 		token         string
@@ -158,15 +184,13 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 				invite := &domain.Invite{
 					Token:     "valid_token",
 					Email:     "test@example.com",
+					Role:      domain.RoleUploader,
 					Expiry:    time.Now().Add(1 * time.Hour),
 					Used:      false,
 					InvitedBy: 1,
 				}
 				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(invite, nil).Once()
-				mockJWTService.On("ValidateToken", "valid_token").Return(map[string]interface{}{"email": "test@example.com"}, nil).Once()
-				updatedInvite := *invite 
-				updatedInvite.Used = true
-				mockInviteRepo.On("Update", ctx, &updatedInvite).Return(&updatedInvite, nil).Once()
+				mockJWTService.On("ValidateInviteToken", "valid_token").Return(&domain.TokenClaims{Email: "test@example.com", Role: domain.RoleUploader, Type: domain.TokenTypeInvite}, nil).Once()
 			},
 			expectedEmail: "test@example.com",
 			expectedErr:   nil,
@@ -175,10 +199,10 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 			name:  "Invite not found",
 			token: "invalid_token",
 			mockSetup: func() {
-				mockInviteRepo.On("FindByToken", ctx, "invalid_token").Return(nil, errors.New("not found")).Once()
+				mockInviteRepo.On("FindByToken", ctx, "invalid_token").Return(nil, config.ErrNotFound).Once()
 			},
 			expectedEmail: "",
-			expectedErr:   config.ErrNotFound,
+			expectedErr:   config.ErrInviteNotFound,
 		},
 		{
 			name:  "Invite already used",
@@ -194,60 +218,56 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 				mockInviteRepo.On("FindByToken", ctx, "used_token").Return(invite, nil).Once()
 			},
 			expectedEmail: "",
-			expectedErr:   config.ErrBadRequest,
+			expectedErr:   config.ErrInviteAccepted,
 		},
 		{
-			name:  "Invite expired",
-			token: "expired_token",
+			name:  "Invite revoked",
+			token: "revoked_token",
 			mockSetup: func() {
 				invite := &domain.Invite{
-					Token:     "expired_token",
+					Token:     "revoked_token",
 					Email:     "test@example.com",
-					Expiry:    time.Now().Add(-1 * time.Hour),
-					Used:      false,
+					Expiry:    time.Now().Add(1 * time.Hour),
+					RevokedAt: &revokedAt,
 					InvitedBy: 1,
 				}
-				mockInviteRepo.On("FindByToken", ctx, "expired_token").Return(invite, nil).Once()
+				mockInviteRepo.On("FindByToken", ctx, "revoked_token").Return(invite, nil).Once()
 			},
 			expectedEmail: "",
-			expectedErr:   config.ErrBadRequest,
+			expectedErr:   config.ErrInviteRevoked,
 		},
 		{
-			name:  "Invalid token",
-			token: "invalid_token",
+			name:  "Invite expired",
+			token: "expired_token",
 			mockSetup: func() {
 				invite := &domain.Invite{
-					Token:     "invalid_token",
+					Token:     "expired_token",
 					Email:     "test@example.com",
-					Expiry:    time.Now().Add(1 * time.Hour),
+					Expiry:    time.Now().Add(-1 * time.Hour),
 					Used:      false,
 					InvitedBy: 1,
 				}
-				mockInviteRepo.On("FindByToken", ctx, "invalid_token").Return(invite, nil).Once()
-				mockJWTService.On("ValidateToken", "invalid_token").Return(map[string]interface{}{}, errors.New("invalid token")).Once()
+				mockInviteRepo.On("FindByToken", ctx, "expired_token").Return(invite, nil).Once()
 			},
 			expectedEmail: "",
-			expectedErr:   config.ErrUnauthorized,
+			expectedErr:   config.ErrInviteExpired,
 		},
 		{
-			name:  "Update failure",
-			token: "valid_token",
+			name:  "Invalid token",
+			token: "forged_token",
 			mockSetup: func() {
 				invite := &domain.Invite{
-					Token:     "valid_token",
+					Token:     "forged_token",
 					Email:     "test@example.com",
 					Expiry:    time.Now().Add(1 * time.Hour),
 					Used:      false,
 					InvitedBy: 1,
 				}
-				mockInviteRepo.On("FindByToken", ctx, "valid_token").Return(invite, nil).Once()
-				mockJWTService.On("ValidateToken", "valid_token").Return(map[string]interface{}{"email": "test@example.com"}, nil).Once()
-				updatedInvite := *invite 
-				updatedInvite.Used = true
-				mockInviteRepo.On("Update", ctx, &updatedInvite).Return(nil, errors.New("update error")).Once()
+				mockInviteRepo.On("FindByToken", ctx, "forged_token").Return(invite, nil).Once()
+				mockJWTService.On("ValidateInviteToken", "forged_token").Return(nil, errors.New("invalid token")).Once()
 			},
 			expectedEmail: "",
-			expectedErr:   config.ErrInternalServer,
+			expectedErr:   config.ErrUnauthorized,
 		},
 	}
 
@@ -257,8 +277,7 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 			email, err := uc.ValidateInvite(ctx, tt.token)
 
 			if tt.expectedErr != nil {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				assert.Equal(t, tt.expectedErr, err)
 				assert.Equal(t, tt.expectedEmail, email)
 			} else {
 				assert.NoError(t, err)
@@ -267,3 +286,100 @@ func TestInviteUseCase_ValidateInvite(t *testing.T) {
 		})
 	}
 }
+
+func TestInviteUseCase_ListInvites(t *testing.T) {
+	ctx := context.Background()
+	mockInviteRepo := mocks.NewInviteRepository(t)
+	uc := NewInviteUseCase(mockInviteRepo, mocks.NewUserRepository(t), &MockEmailService{}, &MockJWTService{})
+
+	mockInviteRepo.On("List", ctx, "").Return([]*domain.Invite{
+		{ID: 1, Expiry: time.Now().Add(time.Hour)},
+		{ID: 2, Expiry: time.Now().Add(-time.Hour)},
+		{ID: 3, Expiry: time.Now().Add(time.Hour), Used: true},
+	}, nil).Once()
+
+	invites, err := uc.ListInvites(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InviteStatusPending, invites[0].Status)
+	assert.Equal(t, domain.InviteStatusExpired, invites[1].Status)
+	assert.Equal(t, domain.InviteStatusUsed, invites[2].Status)
+
+	_, err = uc.ListInvites(ctx, "accepted")
+	assert.Equal(t, config.ErrBadRequest, err)
+}
+
+func TestInviteUseCase_ResendInvite(t *testing.T) {
+	ctx := context.Background()
+	mockInviteRepo := mocks.NewInviteRepository(t)
+	mockEmailService := &MockEmailService{}
+	mockJWTService := &MockJWTService{}
+	uc := NewInviteUseCase(mockInviteRepo, mocks.NewUserRepository(t), mockEmailService, mockJWTService)
+
+	tests := []struct {
+		name         string
+		id           uint
+		mockSetup    func()
+		expectedLink string
+		expectedErr  error
+	}{
+		{
+			name: "Expired invite gets a fresh token",
+			id:   1,
+			mockSetup: func() {
+				invite := &domain.Invite{ID: 1, Token: "old_token", Email: "test@example.com", Role: domain.RoleLoanOfficer, Expiry: time.Now().Add(-time.Hour)}
+				mockInviteRepo.On("FindByID", ctx, uint(1)).Return(invite, nil).Once()
+				mockJWTService.On("GenerateInviteToken", "test@example.com", domain.RoleLoanOfficer, uint(7)).Return("new_token", nil).Once()
+				mockInviteRepo.On("Update", ctx, mock.MatchedBy(func(invite *domain.Invite) bool {
+					return invite.Token == "new_token" && invite.Expiry.After(time.Now())
+				})).Return(invite, nil).Once()
+				mockEmailService.On("SendInvite", "test@example.com", "http://localhost:8080/register?token=new_token").Return(nil).Once()
+			},
+			expectedLink: "http://localhost:8080/register?token=new_token",
+		},
+		{
+			name: "Used invite",
+			id:   2,
+			mockSetup: func() {
+				invite := &domain.Invite{ID: 2, Email: "test@example.com", Expiry: time.Now().Add(time.Hour), Used: true}
+				mockInviteRepo.On("FindByID", ctx, uint(2)).Return(invite, nil).Once()
+			},
+			expectedErr: config.ErrInviteAccepted,
+		},
+		{
+			name: "Invite not found",
+			id:   3,
+			mockSetup: func() {
+				mockInviteRepo.On("FindByID", ctx, uint(3)).Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrInviteNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			link, err := uc.ResendInvite(ctx, 7, tt.id)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedLink, link)
+		})
+	}
+}
+
+func TestInviteUseCase_RevokeInvite(t *testing.T) {
+	ctx := context.Background()
+	mockInviteRepo := mocks.NewInviteRepository(t)
+	uc := NewInviteUseCase(mockInviteRepo, mocks.NewUserRepository(t), &MockEmailService{}, &MockJWTService{})
+
+	pending := &domain.Invite{ID: 1, Expiry: time.Now().Add(time.Hour)}
+	mockInviteRepo.On("FindByID", ctx, uint(1)).Return(pending, nil).Once()
+	mockInviteRepo.On("Update", ctx, pending).Return(pending, nil).Once()
+	invite, err := uc.RevokeInvite(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InviteStatusRevoked, invite.Status)
+
+	used := &domain.Invite{ID: 2, Expiry: time.Now().Add(time.Hour), Used: true}
+	mockInviteRepo.On("FindByID", ctx, uint(2)).Return(used, nil).Once()
+	_, err = uc.RevokeInvite(ctx, 2)
+	assert.Equal(t, config.ErrInviteAccepted, err)
+}
@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type MasterCustomerUseCaseImpl struct {
+	masterRepo domain.MasterCustomerRepository
+	validator  *validator.Validate
+}
+
+func NewMasterCustomerUseCase(masterRepo domain.MasterCustomerRepository) *MasterCustomerUseCaseImpl {
+	return &MasterCustomerUseCaseImpl{
+		masterRepo: masterRepo,
+		validator:  validator.New(),
+	}
+}
+
+func (u *MasterCustomerUseCaseImpl) Create(ctx context.Context, req *domain.MasterCustomerRequest, adminID uint) (*domain.Customer, error) {
+	existing, err := u.masterRepo.FindByAccountNo(ctx, string(req.AccountNo))
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, config.ErrCustomerAlreadyExists
+	}
+	return u.create(ctx, req, adminID)
+}
+
+func (u *MasterCustomerUseCaseImpl) Update(ctx context.Context, id int, req *domain.MasterCustomerRequest, adminID uint) (*domain.Customer, error) {
+	customer, err := u.masterRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if other, err := u.masterRepo.FindByAccountNo(ctx, string(req.AccountNo)); err != nil {
+		return nil, err
+	} else if other != nil && other.ID != customer.ID {
+		return nil, config.ErrCustomerAlreadyExists
+	}
+
+	changes := applyMasterRequest(customer, req)
+	if len(changes) == 0 {
+		return customer, nil
+	}
+	if err := u.validator.Struct(customer); err != nil {
+		return nil, config.ErrInvalidCustomerDetails
+	}
+	return u.masterRepo.Update(ctx, customer, &domain.CustomerChange{
+		AccountNo: customer.AccountNo,
+		Action:    domain.ChangeActionUpdate,
+		Changes:   changes,
+		ChangedBy: adminID,
+	})
+}
+
+// Deactivate hides a master record from customer verification while keeping
+// it, and its history, in the customers table.
+func (u *MasterCustomerUseCaseImpl) Deactivate(ctx context.Context, id int, adminID uint) (*domain.Customer, error) {
+	customer, err := u.masterRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if customer.DeactivatedAt != nil {
+		return customer, nil
+	}
+	now := time.Now()
+	customer.DeactivatedAt = &now
+	return u.masterRepo.Update(ctx, customer, &domain.CustomerChange{
+		AccountNo: customer.AccountNo,
+		Action:    domain.ChangeActionDeactivate,
+		Changes:   domain.FieldChanges{"deactivatedAt": {Old: nil, New: now}},
+		ChangedBy: adminID,
+	})
+}
+
+// BulkUpsert loads a JSON array of master records keyed by account number.
+// New accounts are created, existing ones updated (and reactivated if they had
+// been deactivated); each record gets its own entry in the returned logs.
+func (u *MasterCustomerUseCaseImpl) BulkUpsert(ctx context.Context, file io.Reader, adminID uint) (*domain.BulkUpsertSummary, []*domain.ValidationResult, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	var input []*domain.MasterCustomerRequest
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON format: %v", err)
+	}
+
+	summary := &domain.BulkUpsertSummary{}
+	var logs []*domain.ValidationResult
+
+	for i, req := range input {
+		logEntry := domain.NewValidationResult(i + 1)
+		logs = append(logs, logEntry)
+		if req == nil {
+			req = &domain.MasterCustomerRequest{}
+		}
+
+		req.CustomerName = strings.TrimSpace(req.CustomerName)
+		if req.CustomerName == "" {
+			logEntry.AddError(domain.FieldCustomerName, domain.CodeRequired, "customer name is required")
+		}
+		strippedAccount := strings.TrimLeft(string(req.AccountNo), "0")
+		if strippedAccount == "" || !isNumeric(strippedAccount) {
+			logEntry.AddError(domain.FieldAccountNo, domain.CodeInvalidFormat, "account number is in invalid format/type")
+		}
+		if logEntry.HasErrors() {
+			logEntry.AttemptedName = req.CustomerName
+			logEntry.AttemptedAccountNo = string(req.AccountNo)
+			summary.Failed++
+			continue
+		}
+
+		existing, err := u.masterRepo.FindByAccountNo(ctx, string(req.AccountNo))
+		var saved *domain.Customer
+		switch {
+		case err != nil:
+			logEntry.AddError("", domain.CodeDatabaseError, fmt.Sprintf("database error: %v", err))
+		case existing == nil:
+			saved, err = u.create(ctx, req, adminID)
+			if err == nil {
+				summary.Created++
+			}
+		default:
+			action := domain.ChangeActionUpdate
+			changes := applyMasterRequest(existing, req)
+			if existing.DeactivatedAt != nil {
+				action = domain.ChangeActionReactivate
+				changes["deactivatedAt"] = domain.FieldChange{Old: *existing.DeactivatedAt, New: nil}
+				existing.DeactivatedAt = nil
+			}
+			if len(changes) == 0 {
+				saved = existing
+				summary.Unchanged++
+			} else if u.validator.Struct(existing) != nil {
+				err = config.ErrInvalidCustomerDetails
+			} else {
+				saved, err = u.masterRepo.Update(ctx, existing, &domain.CustomerChange{
+					AccountNo: existing.AccountNo,
+					Action:    action,
+					Changes:   changes,
+					ChangedBy: adminID,
+				})
+				if err == nil {
+					summary.Updated++
+				}
+			}
+		}
+
+		if err == config.ErrInvalidCustomerDetails {
+			logEntry.AddError("", domain.CodeValidationFailed, "validation failed: invalid customer details")
+		} else if err != nil && !logEntry.HasErrors() {
+			logEntry.AddError("", domain.CodeSaveFailed, fmt.Sprintf("failed to save master record: %v", err))
+		}
+		if logEntry.HasErrors() {
+			logEntry.AttemptedName = req.CustomerName
+			logEntry.AttemptedAccountNo = string(req.AccountNo)
+			summary.Failed++
+			continue
+		}
+		logEntry.Verified = true
+		logEntry.NormalizedRecord = saved
+	}
+
+	return summary, logs, nil
+}
+
+func (u *MasterCustomerUseCaseImpl) List(ctx context.Context, includeInactive bool) ([]*domain.Customer, error) {
+	return u.masterRepo.List(ctx, includeInactive)
+}
+
+func (u *MasterCustomerUseCaseImpl) History(ctx context.Context, id int) ([]*domain.CustomerChange, error) {
+	if _, err := u.masterRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.masterRepo.History(ctx, id)
+}
+
+func (u *MasterCustomerUseCaseImpl) create(ctx context.Context, req *domain.MasterCustomerRequest, adminID uint) (*domain.Customer, error) {
+	customerID := strings.TrimSpace(req.CustomerId)
+	if customerID == "" {
+		customerID = fmt.Sprintf("CUST-%s", uuid.New().String()[:8])
+	}
+	customer := &domain.Customer{CustomerId: customerID}
+	changes := applyMasterRequest(customer, req)
+	if err := u.validator.Struct(customer); err != nil {
+		return nil, config.ErrInvalidCustomerDetails
+	}
+	return u.masterRepo.Create(ctx, customer, &domain.CustomerChange{
+		AccountNo: customer.AccountNo,
+		Action:    domain.ChangeActionCreate,
+		Changes:   changes,
+		ChangedBy: adminID,
+	})
+}
+
+// applyMasterRequest copies the request onto customer and returns the fields
+// whose values actually changed.
+func applyMasterRequest(customer *domain.Customer, req *domain.MasterCustomerRequest) domain.FieldChanges {
+	changes := domain.FieldChanges{}
+	setString := func(field string, dst *string, value string) {
+		value = strings.TrimSpace(value)
+		if *dst != value {
+			changes[field] = domain.FieldChange{Old: *dst, New: value}
+			*dst = value
+		}
+	}
+	setString("customerName", &customer.CustomerName, req.CustomerName)
+	setString("mobile", &customer.Mobile, req.Mobile)
+	setString("branchName", &customer.BranchName, req.BranchName)
+	setString("branchCode", &customer.BranchCode, req.BranchCode)
+	setString("productName", &customer.ProductName, req.ProductName)
+
+	accountNo := domain.AccountNo(strings.TrimSpace(string(req.AccountNo)))
+	if customer.AccountNo != accountNo {
+		changes["accountNo"] = domain.FieldChange{Old: customer.AccountNo, New: accountNo}
+		customer.AccountNo = accountNo
+	}
+	if customer.CustomerBalance != req.CustomerBalance {
+		changes["customerBalance"] = domain.FieldChange{Old: customer.CustomerBalance, New: req.CustomerBalance}
+		customer.CustomerBalance = req.CustomerBalance
+	}
+	return changes
+}
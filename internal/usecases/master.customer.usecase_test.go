@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/pkg/config"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMasterCustomerUseCase_Create(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		req         *domain.MasterCustomerRequest
+		mockSetup   func(repo *mocks.MasterCustomerRepository)
+		expectedErr error
+	}{
+		{
+			name: "New account is created with history",
+			req:  &domain.MasterCustomerRequest{CustomerName: "Abebe Kebede", AccountNo: "12345", BranchCode: "BR01"},
+			mockSetup: func(repo *mocks.MasterCustomerRepository) {
+				repo.On("FindByAccountNo", ctx, "12345").Return(nil, nil).Once()
+				repo.On("Create", ctx, mock.MatchedBy(func(c *domain.Customer) bool {
+					return c.CustomerName == "Abebe Kebede" && c.BranchCode == "BR01" && c.CustomerId != ""
+				}), mock.MatchedBy(func(ch *domain.CustomerChange) bool {
+					return ch.Action == domain.ChangeActionCreate && ch.ChangedBy == 1 && ch.Changes["branchCode"].New == "BR01"
+				})).Return(&domain.Customer{ID: 1}, nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Existing account is rejected",
+			req:  &domain.MasterCustomerRequest{CustomerName: "Abebe Kebede", AccountNo: "12345"},
+			mockSetup: func(repo *mocks.MasterCustomerRepository) {
+				repo.On("FindByAccountNo", ctx, "12345").Return(&domain.Customer{ID: 1}, nil).Once()
+			},
+			expectedErr: config.ErrCustomerAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMasterCustomerRepository(t)
+			uc := NewMasterCustomerUseCase(repo)
+			tt.mockSetup(repo)
+
+			_, err := uc.Create(ctx, tt.req, 1)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestMasterCustomerUseCase_Update(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMasterCustomerRepository(t)
+	uc := NewMasterCustomerUseCase(repo)
+
+	existing := &domain.Customer{ID: 1, CustomerId: "CUST-1", CustomerName: "Abebe Kebede", AccountNo: "12345", BranchCode: "BR01"}
+	repo.On("FindByID", ctx, 1).Return(existing, nil).Once()
+	repo.On("FindByAccountNo", ctx, "12345").Return(existing, nil).Once()
+	repo.On("Update", ctx, existing, mock.MatchedBy(func(ch *domain.CustomerChange) bool {
+		return ch.Action == domain.ChangeActionUpdate && len(ch.Changes) == 1 &&
+			ch.Changes["branchCode"].Old == "BR01" && ch.Changes["branchCode"].New == "BR02"
+	})).Return(existing, nil).Once()
+
+	customer, err := uc.Update(ctx, 1, &domain.MasterCustomerRequest{CustomerName: "Abebe Kebede", AccountNo: "12345", BranchCode: "BR02"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "BR02", customer.BranchCode)
+}
+
+func TestMasterCustomerUseCase_Deactivate(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMasterCustomerRepository(t)
+	uc := NewMasterCustomerUseCase(repo)
+
+	repo.On("FindByID", ctx, 1).Return(&domain.Customer{ID: 1, CustomerName: "Abebe Kebede", AccountNo: "12345"}, nil).Once()
+	repo.On("Update", ctx, mock.MatchedBy(func(c *domain.Customer) bool {
+		return c.DeactivatedAt != nil
+	}), mock.MatchedBy(func(ch *domain.CustomerChange) bool {
+		return ch.Action == domain.ChangeActionDeactivate
+	})).Return(&domain.Customer{ID: 1}, nil).Once()
+
+	_, err := uc.Deactivate(ctx, 1, 1)
+	assert.NoError(t, err)
+}
+
+func TestMasterCustomerUseCase_BulkUpsert(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMasterCustomerRepository(t)
+	uc := NewMasterCustomerUseCase(repo)
+
+	deactivatedAt := time.Now().AddDate(0, -1, 0)
+	input := `[
+		{"customerName": "New Customer", "accountNo": "11111"},
+		{"customerName": "Same Customer", "accountNo": "22222"},
+		{"customerName": "Returning Customer", "accountNo": "33333"},
+		{"customerName": "", "accountNo": "abc"}
+	]`
+
+	repo.On("FindByAccountNo", ctx, "11111").Return(nil, nil).Once()
+	repo.On("Create", ctx, mock.AnythingOfType("*domain.Customer"), mock.AnythingOfType("*domain.CustomerChange")).
+		Return(&domain.Customer{ID: 1, CustomerName: "New Customer", AccountNo: "11111"}, nil).Once()
+	repo.On("FindByAccountNo", ctx, "22222").
+		Return(&domain.Customer{ID: 2, CustomerId: "CUST-2", CustomerName: "Same Customer", AccountNo: "22222"}, nil).Once()
+	repo.On("FindByAccountNo", ctx, "33333").
+		Return(&domain.Customer{ID: 3, CustomerId: "CUST-3", CustomerName: "Returning Customer", AccountNo: "33333", DeactivatedAt: &deactivatedAt}, nil).Once()
+	repo.On("Update", ctx, mock.MatchedBy(func(c *domain.Customer) bool {
+		return c.ID == 3 && c.DeactivatedAt == nil
+	}), mock.MatchedBy(func(ch *domain.CustomerChange) bool {
+		return ch.Action == domain.ChangeActionReactivate
+	})).Return(&domain.Customer{ID: 3}, nil).Once()
+
+	summary, logs, err := uc.BulkUpsert(ctx, bytes.NewReader([]byte(input)), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.BulkUpsertSummary{Created: 1, Updated: 1, Unchanged: 1, Failed: 1}, summary)
+	assert.Len(t, logs, 4)
+	assert.True(t, logs[0].Verified)
+	assert.True(t, logs[1].Verified)
+	assert.True(t, logs[2].Verified)
+	assert.False(t, logs[3].Verified)
+	assert.Equal(t, []domain.ValidationErrorCode{domain.CodeRequired, domain.CodeInvalidFormat}, errorCodes(logs[3].Errors))
+}
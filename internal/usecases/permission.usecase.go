@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// permissionCacheTTL bounds how long a grant changed on another instance can
+// take to be seen here; changes made through this instance apply at once.
+const permissionCacheTTL = time.Minute
+
+type PermissionUseCaseImpl struct {
+	repo domain.RolePermissionRepository
+
+	mu       sync.RWMutex
+	grants   map[string]map[string]bool
+	loadedAt time.Time
+}
+
+func NewPermissionUseCase(repo domain.RolePermissionRepository) *PermissionUseCaseImpl {
+	return &PermissionUseCaseImpl{repo: repo}
+}
+
+// HasPermission reports whether role holds permission. Admins hold every
+// permission regardless of the stored grants.
+func (u *PermissionUseCaseImpl) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	if role == domain.RoleAdmin {
+		return true, nil
+	}
+	grants, err := u.cachedGrants(ctx)
+	if err != nil {
+		return false, err
+	}
+	return grants[role][permission], nil
+}
+
+// ListRoles returns every known role with its permissions, sorted by name.
+func (u *PermissionUseCaseImpl) ListRoles(ctx context.Context) ([]*domain.RolePermissions, error) {
+	grants, err := u.cachedGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := []*domain.RolePermissions{}
+	for _, role := range []string{domain.RoleAdmin, domain.RoleAnalyst, domain.RoleLoanOfficer, domain.RoleUploader} {
+		if role == domain.RoleAdmin {
+			roles = append(roles, &domain.RolePermissions{Role: role, Permissions: domain.AllPermissions()})
+			continue
+		}
+		roles = append(roles, &domain.RolePermissions{Role: role, Permissions: sortedPermissions(grants[role])})
+	}
+	return roles, nil
+}
+
+// SetRolePermissions replaces the permissions granted to role.
+func (u *PermissionUseCaseImpl) SetRolePermissions(ctx context.Context, role string, permissions []string) (*domain.RolePermissions, error) {
+	if !domain.IsValidRole(role) {
+		return nil, config.ErrInvalidRole
+	}
+	if role == domain.RoleAdmin {
+		return nil, config.ErrRoleNotEditable
+	}
+	set := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if !domain.IsValidPermission(permission) {
+			return nil, config.ErrInvalidPermission
+		}
+		set[permission] = true
+	}
+	unique := sortedPermissions(set)
+	if err := u.repo.ReplaceForRole(ctx, role, unique); err != nil {
+		return nil, err
+	}
+	u.invalidate()
+	return &domain.RolePermissions{Role: role, Permissions: unique}, nil
+}
+
+// SeedDefaults stores the default grants when no grants are stored yet, so
+// changes made by admins survive restarts.
+func (u *PermissionUseCaseImpl) SeedDefaults(ctx context.Context) error {
+	count, err := u.repo.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	for role, permissions := range domain.DefaultRolePermissions() {
+		if err := u.repo.ReplaceForRole(ctx, role, permissions); err != nil {
+			return err
+		}
+	}
+	u.invalidate()
+	return nil
+}
+
+func (u *PermissionUseCaseImpl) cachedGrants(ctx context.Context) (map[string]map[string]bool, error) {
+	u.mu.RLock()
+	grants, loadedAt := u.grants, u.loadedAt
+	u.mu.RUnlock()
+	if grants != nil && time.Since(loadedAt) < permissionCacheTTL {
+		return grants, nil
+	}
+
+	stored, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grants = make(map[string]map[string]bool)
+	for _, grant := range stored {
+		if grants[grant.Role] == nil {
+			grants[grant.Role] = make(map[string]bool)
+		}
+		grants[grant.Role][grant.Permission] = true
+	}
+	u.mu.Lock()
+	u.grants, u.loadedAt = grants, time.Now()
+	u.mu.Unlock()
+	return grants, nil
+}
+
+func (u *PermissionUseCaseImpl) invalidate() {
+	u.mu.Lock()
+	u.grants = nil
+	u.mu.Unlock()
+}
+
+func sortedPermissions(set map[string]bool) []string {
+	permissions := make([]string, 0, len(set))
+	for permission := range set {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
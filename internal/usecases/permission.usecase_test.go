@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionUseCase_HasPermission(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewRolePermissionRepository(t)
+	uc := NewPermissionUseCase(mockRepo)
+
+	mockRepo.On("List", ctx).Return([]*domain.RolePermission{
+		{Role: domain.RoleUploader, Permission: domain.PermissionCustomersImport},
+		{Role: domain.RoleAnalyst, Permission: domain.PermissionRatingsRead},
+	}, nil).Once()
+
+	tests := []struct {
+		name       string
+		role       string
+		permission string
+		expected   bool
+	}{
+		{name: "Granted", role: domain.RoleUploader, permission: domain.PermissionCustomersImport, expected: true},
+		{name: "Not granted", role: domain.RoleUploader, permission: domain.PermissionRatingsRead, expected: false},
+		{name: "Unknown role", role: "guest", permission: domain.PermissionCustomersRead, expected: false},
+		{name: "Admin holds every permission", role: domain.RoleAdmin, permission: domain.PermissionRolesManage, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := uc.HasPermission(ctx, tt.role, tt.permission)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestPermissionUseCase_SetRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewRolePermissionRepository(t)
+	uc := NewPermissionUseCase(mockRepo)
+
+	tests := []struct {
+		name        string
+		role        string
+		permissions []string
+		mockSetup   func()
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:        "Grants replaced and deduplicated",
+			role:        domain.RoleAnalyst,
+			permissions: []string{domain.PermissionRatingsRead, domain.PermissionCustomersRead, domain.PermissionRatingsRead},
+			mockSetup: func() {
+				mockRepo.On("ReplaceForRole", ctx, domain.RoleAnalyst, []string{domain.PermissionCustomersRead, domain.PermissionRatingsRead}).Return(nil).Once()
+			},
+			expected: []string{domain.PermissionCustomersRead, domain.PermissionRatingsRead},
+		},
+		{
+			name:        "Unknown permission",
+			role:        domain.RoleAnalyst,
+			permissions: []string{"customers:delete"},
+			mockSetup:   func() {},
+			expectedErr: config.ErrInvalidPermission,
+		},
+		{
+			name:        "Unknown role",
+			role:        "guest",
+			permissions: []string{domain.PermissionCustomersRead},
+			mockSetup:   func() {},
+			expectedErr: config.ErrInvalidRole,
+		},
+		{
+			name:        "Admin role",
+			role:        domain.RoleAdmin,
+			permissions: []string{},
+			mockSetup:   func() {},
+			expectedErr: config.ErrRoleNotEditable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			role, err := uc.SetRolePermissions(ctx, tt.role, tt.permissions)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, role)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, role.Permissions)
+			}
+		})
+	}
+}
+
+func TestPermissionUseCase_SetRolePermissionsRefreshesCache(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewRolePermissionRepository(t)
+	uc := NewPermissionUseCase(mockRepo)
+
+	mockRepo.On("List", ctx).Return([]*domain.RolePermission{}, nil).Once()
+	allowed, err := uc.HasPermission(ctx, domain.RoleLoanOfficer, domain.PermissionLoansApprove)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	mockRepo.On("ReplaceForRole", ctx, domain.RoleLoanOfficer, []string{domain.PermissionLoansApprove}).Return(nil).Once()
+	_, err = uc.SetRolePermissions(ctx, domain.RoleLoanOfficer, []string{domain.PermissionLoansApprove})
+	assert.NoError(t, err)
+
+	mockRepo.On("List", ctx).Return([]*domain.RolePermission{
+		{Role: domain.RoleLoanOfficer, Permission: domain.PermissionLoansApprove},
+	}, nil).Once()
+	allowed, err = uc.HasPermission(ctx, domain.RoleLoanOfficer, domain.PermissionLoansApprove)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestPermissionUseCase_SeedDefaults(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewRolePermissionRepository(t)
+	uc := NewPermissionUseCase(mockRepo)
+
+	mockRepo.On("Count", ctx).Return(int64(0), nil).Once()
+	for role, permissions := range domain.DefaultRolePermissions() {
+		mockRepo.On("ReplaceForRole", ctx, role, permissions).Return(nil).Once()
+	}
+	assert.NoError(t, uc.SeedDefaults(ctx))
+
+	mockRepo.On("Count", ctx).Return(int64(4), nil).Once()
+	assert.NoError(t, uc.SeedDefaults(ctx))
+}
@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// NewRatingStrategy returns the strategy registered under name. An empty name
+// selects no strategy, which callers use to turn shadow mode off.
+func NewRatingStrategy(name string) (domain.RatingStrategy, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case domain.StrategyScorecard:
+		return NewScorecardStrategy(), nil
+	case domain.StrategyRules:
+		return NewRulesStrategy(nil), nil
+	default:
+		return nil, fmt.Errorf("unknown rating strategy %q", name)
+	}
+}
+
+// ScorecardStrategy is the weighted-factor formula configured by the
+// customer's scorecard.
+type ScorecardStrategy struct{}
+
+func NewScorecardStrategy() *ScorecardStrategy {
+	return &ScorecardStrategy{}
+}
+
+func (s *ScorecardStrategy) Name() string {
+	return domain.StrategyScorecard
+}
+
+func (s *ScorecardStrategy) Rate(input *domain.RatingInput) *domain.RatingResult {
+	scorecard := input.Scorecard
+	result := newRatingResult(s.Name(), input)
+	if len(input.Transactions) == 0 {
+		for _, factor := range scorecard.Factors {
+			result.Factors = append(result.Factors, domain.FactorBreakdown{Name: factor.Name, Weight: factor.Weight})
+		}
+		return withoutTransactions(result, scorecard)
+	}
+
+	raw, salary := rawRatingFactors(input)
+	var weighted float64
+	for _, factor := range scorecard.Factors {
+		score := normalizeFactor(factor, raw[factor.Name])
+		weighted += factor.Weight * score
+		result.Factors = append(result.Factors, domain.FactorBreakdown{
+			Name:            factor.Name,
+			RawValue:        roundTo(raw[factor.Name], 2),
+			NormalizedScore: roundTo(score, 3),
+			Weight:          factor.Weight,
+			Contribution:    roundTo(factor.Weight*score*scorecard.Scale, 2),
+		})
+	}
+	result.Reasons = ratingReasons(result.Factors)
+	return withRating(result, weighted*scorecard.Scale, scorecard, salary)
+}
+
+// RulesStrategy starts every customer at the scorecard's minimum rating and
+// adds the points of each rule the customer meets. In its breakdown a rule's
+// Weight is its points and NormalizedScore is 1 when it is met, 0 otherwise.
+type RulesStrategy struct {
+	rules []domain.RatingRule
+}
+
+// NewRulesStrategy returns a rules-based strategy; nil rules selects
+// domain.DefaultRatingRules.
+func NewRulesStrategy(rules []domain.RatingRule) *RulesStrategy {
+	if rules == nil {
+		rules = domain.DefaultRatingRules()
+	}
+	return &RulesStrategy{rules: rules}
+}
+
+func (s *RulesStrategy) Name() string {
+	return domain.StrategyRules
+}
+
+func (s *RulesStrategy) Rate(input *domain.RatingInput) *domain.RatingResult {
+	scorecard := input.Scorecard
+	result := newRatingResult(s.Name(), input)
+	if len(input.Transactions) == 0 {
+		for _, rule := range s.rules {
+			result.Factors = append(result.Factors, domain.FactorBreakdown{Name: rule.Factor, Weight: rule.Points})
+		}
+		return withoutTransactions(result, scorecard)
+	}
+
+	raw, salary := rawRatingFactors(input)
+	rating := scorecard.MinRating
+	for _, rule := range s.rules {
+		value := raw[rule.Factor]
+		breakdown := domain.FactorBreakdown{Name: rule.Factor, RawValue: roundTo(value, 2), Weight: rule.Points}
+		if value >= rule.Min && (rule.Max == nil || value <= *rule.Max) {
+			breakdown.NormalizedScore = 1
+			breakdown.Contribution = rule.Points
+			rating += rule.Points
+		}
+		result.Factors = append(result.Factors, breakdown)
+	}
+
+	// Unmet rules are explained in order of the points they would have added.
+	unmet := append([]domain.FactorBreakdown(nil), result.Factors...)
+	sort.SliceStable(unmet, func(i, j int) bool { return unmet[i].Weight > unmet[j].Weight })
+	result.Reasons = ratingReasons(unmet)
+	return withRating(result, rating, scorecard, salary)
+}
+
+func newRatingResult(strategy string, input *domain.RatingInput) *domain.RatingResult {
+	return &domain.RatingResult{
+		CustomerID:       input.CustomerID,
+		ScorecardVersion: input.Scorecard.Version,
+		Strategy:         strategy,
+		AsOf:             input.AsOf,
+		Factors:          []domain.FactorBreakdown{},
+		Reasons:          []domain.RatingReason{},
+	}
+}
+
+// withoutTransactions gives a customer with no history the minimum rating.
+func withoutTransactions(result *domain.RatingResult, scorecard *domain.Scorecard) *domain.RatingResult {
+	result.Rating = scorecard.MinRating
+	result.Reasons = append(result.Reasons, domain.RatingReason{
+		Code:    domain.ReasonNoTransactions,
+		Message: "No transactions on the account",
+	})
+	return result
+}
+
+// withRating rounds the rating to one decimal, clamps it to the scorecard's
+// range and derives the advance limit from the estimated salary.
+func withRating(result *domain.RatingResult, rating float64, scorecard *domain.Scorecard, salary salaryEstimate) *domain.RatingResult {
+	rating = math.Round(rating*10) / 10
+	if rating < scorecard.MinRating {
+		rating = scorecard.MinRating
+	} else if rating > scorecard.MaxRating {
+		rating = scorecard.MaxRating
+	}
+	result.Rating = rating
+	result.EstimatedMonthlySalary = salary.MonthlyAmount
+	result.EligibleLimit = roundTo(salary.MonthlyAmount*scorecard.LimitRatio*rating/scorecard.MaxRating, 2)
+	return result
+}
+
+// rawRatingFactors computes the raw value of every known factor.
+func rawRatingFactors(input *domain.RatingInput) (map[string]float64, salaryEstimate) {
+	salary := detectSalary(input.Customer.AccountNo, input.Transactions)
+	raw := ratingFactors(input.Customer, input.Transactions)
+	raw[domain.FactorSalary] = float64(salary.Credits)
+	return raw, salary
+}
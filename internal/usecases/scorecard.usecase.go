@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+type ScorecardUseCaseImpl struct {
+	scorecardRepo domain.ScorecardRepository
+}
+
+func NewScorecardUseCase(scorecardRepo domain.ScorecardRepository) *ScorecardUseCaseImpl {
+	return &ScorecardUseCaseImpl{scorecardRepo: scorecardRepo}
+}
+
+func (u *ScorecardUseCaseImpl) Create(ctx context.Context, req *domain.ScorecardRequest, adminID uint) (*domain.Scorecard, error) {
+	scorecard, err := buildScorecard(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.scorecardRepo.FindByVersion(ctx, scorecard.ProductName, scorecard.Version); err == nil {
+		return nil, config.ErrScorecardExists
+	} else if err != config.ErrScorecardNotFound {
+		return nil, err
+	}
+	scorecard.CreatedBy = adminID
+	return u.scorecardRepo.Create(ctx, scorecard)
+}
+
+func (u *ScorecardUseCaseImpl) List(ctx context.Context, productName string) ([]*domain.Scorecard, error) {
+	return u.scorecardRepo.List(ctx, productName)
+}
+
+func (u *ScorecardUseCaseImpl) Activate(ctx context.Context, productName string, version string) (*domain.Scorecard, error) {
+	scorecard, err := u.scorecardRepo.FindByVersion(ctx, productName, version)
+	if err != nil {
+		return nil, err
+	}
+	if scorecard.Active {
+		return scorecard, nil
+	}
+	return u.scorecardRepo.Activate(ctx, scorecard)
+}
+
+// LoadDefinitions reads a JSON array of scorecard definitions, as used by the
+// SCORECARD_FILE setting, and stores the versions not already present. It
+// returns the number of scorecards created.
+func (u *ScorecardUseCaseImpl) LoadDefinitions(ctx context.Context, file io.Reader) (int, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %v", err)
+	}
+	var input []*domain.ScorecardRequest
+	if err := json.Unmarshal(data, &input); err != nil {
+		return 0, fmt.Errorf("invalid JSON format: %v", err)
+	}
+
+	created := 0
+	for i, req := range input {
+		if req == nil {
+			return created, fmt.Errorf("scorecard %d: %w", i+1, config.ErrInvalidScorecard)
+		}
+		_, err := u.Create(ctx, req, 0)
+		if err == config.ErrScorecardExists {
+			continue
+		}
+		if err != nil {
+			return created, fmt.Errorf("scorecard %d (%s): %w", i+1, req.Version, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// buildScorecard validates a definition and fills in the defaults of the
+// original formula: a 0-10 scale clamped to [1, 10], and a limit of half the
+// monthly salary. New scorecards are active unless the request says otherwise.
+func buildScorecard(req *domain.ScorecardRequest) (*domain.Scorecard, error) {
+	version := strings.TrimSpace(req.Version)
+	if version == "" || len(req.Factors) == 0 {
+		return nil, config.ErrInvalidScorecard
+	}
+
+	known := map[string]bool{
+		domain.FactorCount:     true,
+		domain.FactorVolume:    true,
+		domain.FactorDuration:  true,
+		domain.FactorStability: true,
+		domain.FactorSalary:    true,
+	}
+	seen := make(map[string]bool)
+	var totalWeight float64
+	for _, factor := range req.Factors {
+		if !known[factor.Name] || seen[factor.Name] || factor.Weight < 0 || factor.Cap <= 0 {
+			return nil, config.ErrInvalidScorecard
+		}
+		seen[factor.Name] = true
+		totalWeight += factor.Weight
+	}
+	if math.Abs(totalWeight-1) > 0.001 {
+		return nil, config.ErrInvalidScorecard
+	}
+
+	scorecard := &domain.Scorecard{
+		Version:     version,
+		ProductName: strings.TrimSpace(req.ProductName),
+		Factors:     domain.ScorecardFactors(req.Factors),
+		Scale:       req.Scale,
+		MinRating:   req.MinRating,
+		MaxRating:   req.MaxRating,
+		LimitRatio:  req.LimitRatio,
+		Active:      req.Active == nil || *req.Active,
+	}
+	if scorecard.Scale == 0 {
+		scorecard.Scale = 10
+	}
+	if scorecard.MinRating == 0 {
+		scorecard.MinRating = 1
+	}
+	if scorecard.MaxRating == 0 {
+		scorecard.MaxRating = 10
+	}
+	if scorecard.LimitRatio == 0 {
+		scorecard.LimitRatio = 0.5
+	}
+	if scorecard.Scale < 0 || scorecard.MinRating >= scorecard.MaxRating || scorecard.LimitRatio < 0 || scorecard.LimitRatio > 1 {
+		return nil, config.ErrInvalidScorecard
+	}
+	return scorecard, nil
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/pkg/config"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScorecardUseCase_Create(t *testing.T) {
+	ctx := context.Background()
+	validFactors := []domain.ScorecardFactor{
+		{Name: domain.FactorCount, Weight: 0.5, Cap: 20},
+		{Name: domain.FactorVolume, Weight: 0.5, Cap: 50000},
+	}
+
+	tests := []struct {
+		name        string
+		req         *domain.ScorecardRequest
+		mockSetup   func(repo *mocks.ScorecardRepository)
+		expectedErr error
+	}{
+		{
+			name: "Valid scorecard is created active with defaults",
+			req:  &domain.ScorecardRequest{Version: "v2", ProductName: "Payroll", Factors: validFactors},
+			mockSetup: func(repo *mocks.ScorecardRepository) {
+				repo.On("FindByVersion", ctx, "Payroll", "v2").Return(nil, config.ErrScorecardNotFound).Once()
+				repo.On("Create", ctx, mock.MatchedBy(func(s *domain.Scorecard) bool {
+					return s.Active && s.Scale == 10 && s.MinRating == 1 && s.MaxRating == 10 && s.CreatedBy == 1
+				})).Return(&domain.Scorecard{Version: "v2"}, nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Weights must sum to one",
+			req: &domain.ScorecardRequest{Version: "v2", Factors: []domain.ScorecardFactor{
+				{Name: domain.FactorCount, Weight: 0.5, Cap: 20},
+			}},
+			mockSetup:   func(repo *mocks.ScorecardRepository) {},
+			expectedErr: config.ErrInvalidScorecard,
+		},
+		{
+			name: "Unknown factors are rejected",
+			req: &domain.ScorecardRequest{Version: "v2", Factors: []domain.ScorecardFactor{
+				{Name: "age", Weight: 1, Cap: 20},
+			}},
+			mockSetup:   func(repo *mocks.ScorecardRepository) {},
+			expectedErr: config.ErrInvalidScorecard,
+		},
+		{
+			name: "Existing version is rejected",
+			req:  &domain.ScorecardRequest{Version: "v2", Factors: validFactors},
+			mockSetup: func(repo *mocks.ScorecardRepository) {
+				repo.On("FindByVersion", ctx, "", "v2").Return(&domain.Scorecard{Version: "v2"}, nil).Once()
+			},
+			expectedErr: config.ErrScorecardExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewScorecardRepository(t)
+			uc := NewScorecardUseCase(repo)
+			tt.mockSetup(repo)
+
+			_, err := uc.Create(ctx, tt.req, 1)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestScorecardUseCase_LoadDefinitions(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewScorecardRepository(t)
+	uc := NewScorecardUseCase(repo)
+
+	input := `[
+		{"version": "v1", "factors": [{"name": "count", "weight": 1, "cap": 10}]},
+		{"version": "v2", "productName": "Payroll", "active": false, "factors": [{"name": "volume", "weight": 1, "cap": 5000}]}
+	]`
+	repo.On("FindByVersion", ctx, "", "v1").Return(&domain.Scorecard{Version: "v1"}, nil).Once()
+	repo.On("FindByVersion", ctx, "Payroll", "v2").Return(nil, config.ErrScorecardNotFound).Once()
+	repo.On("Create", ctx, mock.MatchedBy(func(s *domain.Scorecard) bool {
+		return s.Version == "v2" && !s.Active
+	})).Return(&domain.Scorecard{Version: "v2"}, nil).Once()
+
+	created, err := uc.LoadDefinitions(ctx, bytes.NewReader([]byte(input)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+}
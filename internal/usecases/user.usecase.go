@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"time"
+)
+
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+type UserUseCaseImpl struct {
+	userRepo  domain.UserRepository
+	tokenRepo domain.RefreshTokenRepository
+}
+
+func NewUserUseCase(userRepo domain.UserRepository, tokenRepo domain.RefreshTokenRepository) *UserUseCaseImpl {
+	return &UserUseCaseImpl{userRepo: userRepo, tokenRepo: tokenRepo}
+}
+
+// ListUsers returns a page of users. Pages start at 1; a page size outside
+// 1..maxUserPageSize falls back to the default.
+func (u *UserUseCaseImpl) ListUsers(ctx context.Context, page, pageSize int) (*domain.UserPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxUserPageSize {
+		pageSize = defaultUserPageSize
+	}
+	users, total, err := u.userRepo.List(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []*domain.User{}
+	}
+	return &domain.UserPage{Users: users, Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (u *UserUseCaseImpl) GetUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(ctx, id)
+	if err == config.ErrNotFound {
+		return nil, config.ErrUserNotFound
+	}
+	return user, err
+}
+
+// UpdateRole takes effect on the user's next token refresh.
+func (u *UserUseCaseImpl) UpdateRole(ctx context.Context, adminID, id uint, role string) (*domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return nil, config.ErrInvalidRole
+	}
+	if adminID == id {
+		return nil, config.ErrSelfModification
+	}
+	user, err := u.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	user.Role = role
+	if _, err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return user, nil
+}
+
+// Deactivate blocks the user from logging in and revokes their refresh
+// tokens. Access tokens already issued stay valid until they expire.
+func (u *UserUseCaseImpl) Deactivate(ctx context.Context, adminID, id uint) (*domain.User, error) {
+	if adminID == id {
+		return nil, config.ErrSelfModification
+	}
+	user, err := u.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return user, nil
+	}
+	now := time.Now()
+	user.DeactivatedAt = &now
+	if _, err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, config.ErrInternalServer
+	}
+	if err := u.tokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *UserUseCaseImpl) Reactivate(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := u.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsActive() {
+		return user, nil
+	}
+	user.DeactivatedAt = nil
+	if _, err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, config.ErrInternalServer
+	}
+	return user, nil
+}
+
+// DeleteUser revokes the user's refresh tokens and removes the account.
+func (u *UserUseCaseImpl) DeleteUser(ctx context.Context, adminID, id uint) error {
+	if adminID == id {
+		return config.ErrSelfModification
+	}
+	if _, err := u.GetUser(ctx, id); err != nil {
+		return err
+	}
+	if err := u.tokenRepo.RevokeAllForUser(ctx, id); err != nil {
+		return err
+	}
+	if err := u.userRepo.Delete(ctx, id); err != nil {
+		if err == config.ErrNotFound {
+			return config.ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
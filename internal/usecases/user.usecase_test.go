@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"SalaryAdvance/internal/domain"
+	"SalaryAdvance/internal/mocks"
+	"SalaryAdvance/pkg/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserUseCase_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	uc := NewUserUseCase(mockUserRepo, mocks.NewRefreshTokenRepository(t))
+
+	tests := []struct {
+		name             string
+		page             int
+		pageSize         int
+		mockSetup        func()
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{
+			name:     "Requested page",
+			page:     3,
+			pageSize: 10,
+			mockSetup: func() {
+				mockUserRepo.On("List", ctx, 20, 10).Return([]*domain.User{{ID: 21}}, int64(21), nil).Once()
+			},
+			expectedPage:     3,
+			expectedPageSize: 10,
+		},
+		{
+			name:     "Defaults for out of range values",
+			page:     0,
+			pageSize: 1000,
+			mockSetup: func() {
+				mockUserRepo.On("List", ctx, 0, defaultUserPageSize).Return(nil, int64(0), nil).Once()
+			},
+			expectedPage:     1,
+			expectedPageSize: defaultUserPageSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			page, err := uc.ListUsers(ctx, tt.page, tt.pageSize)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPage, page.Page)
+			assert.Equal(t, tt.expectedPageSize, page.PageSize)
+			assert.NotNil(t, page.Users)
+		})
+	}
+}
+
+func TestUserUseCase_UpdateRole(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	uc := NewUserUseCase(mockUserRepo, mocks.NewRefreshTokenRepository(t))
+
+	tests := []struct {
+		name         string
+		id           uint
+		role         string
+		mockSetup    func()
+		expectedRole string
+		expectedErr  error
+	}{
+		{
+			name: "Role changed",
+			id:   2,
+			role: domain.RoleAdmin,
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(2)).Return(&domain.User{ID: 2, Role: domain.RoleUploader}, nil).Once()
+				mockUserRepo.On("Update", ctx, mock.MatchedBy(func(user *domain.User) bool {
+					return user.Role == domain.RoleAdmin
+				})).Return(&domain.User{}, nil).Once()
+			},
+			expectedRole: domain.RoleAdmin,
+		},
+		{
+			name:        "Unknown role",
+			id:          2,
+			role:        "superuser",
+			mockSetup:   func() {},
+			expectedErr: config.ErrInvalidRole,
+		},
+		{
+			name:        "Own account",
+			id:          1,
+			role:        domain.RoleUploader,
+			mockSetup:   func() {},
+			expectedErr: config.ErrSelfModification,
+		},
+		{
+			name: "User not found",
+			id:   9,
+			role: domain.RoleAdmin,
+			mockSetup: func() {
+				mockUserRepo.On("FindByID", ctx, uint(9)).Return(nil, config.ErrNotFound).Once()
+			},
+			expectedErr: config.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			user, err := uc.UpdateRole(ctx, 1, tt.id, tt.role)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRole, user.Role)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_DeactivateAndReactivate(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	uc := NewUserUseCase(mockUserRepo, mockTokenRepo)
+
+	user := &domain.User{ID: 2, Role: domain.RoleUploader}
+	mockUserRepo.On("FindByID", ctx, uint(2)).Return(user, nil).Twice()
+	mockUserRepo.On("Update", ctx, user).Return(user, nil).Twice()
+	mockTokenRepo.On("RevokeAllForUser", ctx, uint(2)).Return(nil).Once()
+
+	deactivated, err := uc.Deactivate(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.False(t, deactivated.IsActive())
+
+	reactivated, err := uc.Reactivate(ctx, 2)
+	assert.NoError(t, err)
+	assert.True(t, reactivated.IsActive())
+
+	_, err = uc.Deactivate(ctx, 1, 1)
+	assert.Equal(t, config.ErrSelfModification, err)
+}
+
+func TestUserUseCase_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewRefreshTokenRepository(t)
+	uc := NewUserUseCase(mockUserRepo, mockTokenRepo)
+
+	deactivatedAt := time.Now()
+	mockUserRepo.On("FindByID", ctx, uint(2)).Return(&domain.User{ID: 2, DeactivatedAt: &deactivatedAt}, nil).Once()
+	mockTokenRepo.On("RevokeAllForUser", ctx, uint(2)).Return(nil).Once()
+	mockUserRepo.On("Delete", ctx, uint(2)).Return(nil).Once()
+	assert.NoError(t, uc.DeleteUser(ctx, 1, 2))
+
+	mockUserRepo.On("FindByID", ctx, uint(3)).Return(nil, config.ErrNotFound).Once()
+	assert.Equal(t, config.ErrUserNotFound, uc.DeleteUser(ctx, 1, 3))
+
+	assert.Equal(t, config.ErrSelfModification, uc.DeleteUser(ctx, 1, 1))
+}
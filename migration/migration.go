@@ -11,5 +11,13 @@ func MigrateModels(db *gorm.DB) error {
 		&domain.User{},
 		&domain.Customer{},
 		&domain.Transaction{},
+		&domain.CustomerReview{},
+		&domain.CustomerChange{},
+		&domain.Scorecard{},
+		&domain.RatingSnapshot{},
+		&domain.RepaymentOutcome{},
+		&domain.RefreshToken{},
+		&domain.RolePermission{},
+		&domain.PasswordReset{},
 	)
 }
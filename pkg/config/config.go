@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -23,8 +24,25 @@ type Config struct {
 	PrivateKeyPEM string
 	PublicKeyPEM  string
 	Issuer        string
+	Audience      string
 	AccessTTLMin  int
 	RefreshTTLMin int
+
+	PrivateKeyFile   string
+	PublicKeyFile    string
+	KeyDir           string
+	KeyRotationHours int
+	KeyOverlapHours  int
+
+	PasswordResetTTLMin int
+
+	NameMatchAutoThreshold   float64
+	NameMatchReviewThreshold float64
+	FieldPrecedence          map[string]string
+	ScorecardFile            string
+	RatingBatchWorkers       int
+	RatingStrategy           string
+	ShadowRatingStrategy     string
 }
 
 func LoadConfig() Config {
@@ -52,8 +70,25 @@ func LoadConfig() Config {
 		PrivateKeyPEM: getenv("JWT_PRIVATE_KEY", ""),
 		PublicKeyPEM:  getenv("JWT_PUBLIC_KEY", ""),
 		Issuer:        getenv("JWT_ISSUER", "invite-rs256"),
+		Audience:      getenv("JWT_AUDIENCE", "salary-advance-api"),
 		AccessTTLMin:  getenvInt("ACCESS_TTL_MIN", 15),
 		RefreshTTLMin: getenvInt("REFRESH_TTL_MIN", 60*24*7),
+
+		PrivateKeyFile:   getenv("JWT_PRIVATE_KEY_FILE", ""),
+		PublicKeyFile:    getenv("JWT_PUBLIC_KEY_FILE", ""),
+		KeyDir:           getenv("JWT_KEY_DIR", ""),
+		KeyRotationHours: getenvInt("JWT_KEY_ROTATION_HOURS", 0),
+		KeyOverlapHours:  getenvInt("JWT_KEY_OVERLAP_HOURS", 0),
+
+		PasswordResetTTLMin: getenvInt("PASSWORD_RESET_TTL_MIN", 30),
+
+		NameMatchAutoThreshold:   getenvFloat("NAME_MATCH_AUTO_THRESHOLD", 0.9),
+		NameMatchReviewThreshold: getenvFloat("NAME_MATCH_REVIEW_THRESHOLD", 0.75),
+		FieldPrecedence:          parsePrecedence(getenv("CUSTOMER_FIELD_PRECEDENCE", "")),
+		ScorecardFile:            getenv("SCORECARD_FILE", ""),
+		RatingBatchWorkers:       getenvInt("RATING_BATCH_WORKERS", 4),
+		RatingStrategy:           getenv("RATING_STRATEGY", "scorecard"),
+		ShadowRatingStrategy:     getenv("RATING_SHADOW_STRATEGY", ""),
 	}
 
 	log.Printf("issuer=%s access=%d refresh=%d", cfg.Issuer, cfg.AccessTTLMin, cfg.RefreshTTLMin)
@@ -77,3 +112,27 @@ func getenvInt(key string, def int) int {
 	}
 	return def
 }
+
+func getenvFloat(key string, def float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err == nil && f > 0 {
+			return f
+		}
+	}
+	return def
+}
+
+// parsePrecedence reads a list such as "mobile=import,branchName=master" into
+// a field -> preferred source map. Malformed entries are skipped.
+func parsePrecedence(spec string) map[string]string {
+	precedence := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		field, source, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || field == "" || source == "" {
+			continue
+		}
+		precedence[strings.TrimSpace(field)] = strings.ToLower(strings.TrimSpace(source))
+	}
+	return precedence
+}
@@ -19,10 +19,17 @@ var (
 	ErrValidationFailed      = errors.New("customer validation failed")
 	ErrNoValidationLogsFound = errors.New("no validation logs found")
 	ErrInvalidUploaderID     = errors.New("invalid uploader ID")
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrReviewAlreadyResolved = errors.New("review already resolved")
 
 	// Rating errors
 	ErrRatingNotFound        = errors.New("rating not found")
 	ErrCannotCalculateRating = errors.New("unable to calculate rating")
+	ErrScorecardNotFound     = errors.New("scorecard not found")
+	ErrInvalidScorecard      = errors.New("invalid scorecard definition")
+	ErrScorecardExists       = errors.New("scorecard version already exists")
+	ErrInvalidOutcome        = errors.New("invalid repayment outcome")
+	ErrNoOutcomes            = errors.New("no repayment outcomes to backtest")
 
 	// Authorization / Auth errors
 	ErrUnauthorizedAccess    = errors.New("unauthorized access")
@@ -35,6 +42,17 @@ var (
 	ErrInviteNotFound        = errors.New("invite not found")
 	ErrInviteExpired         = errors.New("invite expired")
 	ErrInviteAccepted        = errors.New("invite already accepted")
+	ErrInviteRevoked         = errors.New("invite revoked")
+	ErrRefreshTokenReused    = errors.New("refresh token reuse detected, session revoked")
+	ErrUserDeactivated       = errors.New("account deactivated")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrSelfModification      = errors.New("admins cannot change the role of, deactivate or delete their own account")
+	ErrInvalidPermission     = errors.New("invalid permission")
+	ErrRoleNotEditable       = errors.New("the admin role always holds every permission")
+	ErrResetTokenInvalid     = errors.New("password reset link is invalid, expired or already used")
+	ErrIncorrectPassword     = errors.New("current password is incorrect")
+	ErrSessionNotFound       = errors.New("session not found")
 	// Generic / HTTP errors
 	ErrBadRequest      = errors.New("bad request")
 	ErrInternalServer  = errors.New("internal server error")
@@ -49,19 +67,21 @@ func GetStatusCode(err error) int {
 		return http.StatusOK
 
 	// Bad request errors
-	case ErrInvalidCustomerDetails, ErrInvalidTransactionPayload, ErrBadRequest:
+	case ErrInvalidCustomerDetails, ErrInvalidTransactionPayload, ErrBadRequest, ErrInvalidScorecard, ErrInvalidOutcome, ErrNoOutcomes, ErrInvalidRole, ErrSelfModification, ErrInvalidPermission, ErrRoleNotEditable, ErrResetTokenInvalid, ErrIncorrectPassword, ErrInviteExpired, ErrInviteRevoked:
 		return http.StatusBadRequest
 
 	// Unauthorized errors
-	case ErrUnauthorizedAccess, ErrTokenExpired, ErrInvalidCredentials, ErrInsufficientPrivilege:
+	case ErrUnauthorizedAccess, ErrTokenExpired, ErrInvalidCredentials, ErrInsufficientPrivilege, ErrUnauthorized, ErrRefreshTokenReused:
 		return http.StatusUnauthorized
+	case ErrForbidden, ErrUserDeactivated:
+		return http.StatusForbidden
 
 	// Conflict errors
-	case ErrCustomerAlreadyExists, ErrConflict:
+	case ErrCustomerAlreadyExists, ErrConflict, ErrReviewAlreadyResolved, ErrScorecardExists, ErrInviteAccepted:
 		return http.StatusConflict
 
 	// Not found errors
-	case ErrCustomerNotFound, ErrTransactionNotFound, ErrRatingNotFound, ErrNoValidationLogsFound, ErrNotFound:
+	case ErrCustomerNotFound, ErrTransactionNotFound, ErrRatingNotFound, ErrNoValidationLogsFound, ErrNotFound, ErrReviewNotFound, ErrScorecardNotFound, ErrUserNotFound, ErrInviteNotFound, ErrSessionNotFound:
 		return http.StatusNotFound
 	case ErrTooManyRequests:
 		return http.StatusTooManyRequests